@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+const (
+	messageSeparator = "; "
+)
+
+var (
+	ErrInvalidTarget   = errors.New("nemo: bind target must be a non-nil pointer")
+	ErrNotConvertible  = errors.New("nemo: value not convertible")
+	ErrMissingRequired = errors.New("nemo: required key is missing")
+)
+
+var (
+	_ error = (*FieldError)(nil)
+	_ error = (*BindError)(nil)
+)
+
+// ----------------------------------------------------------------
+
+// FieldError describes the failure of binding the value of one key.
+type FieldError struct {
+	Key        string       // the full key path, e.g.: a.b.servers[0].port
+	Value      any          // the source value
+	SourceType reflect.Type // the type of the source value
+	TargetType reflect.Type // the type of the target field
+	Err        error        // the cause
+}
+
+func newFieldError(key string, value any, target reflect.Type, err error) *FieldError {
+	return &FieldError{
+		Key:        key,
+		Value:      value,
+		SourceType: reflect.TypeOf(value),
+		TargetType: target,
+		Err:        err,
+	}
+}
+
+func (fe *FieldError) Error() string {
+	return fmt.Sprintf("nemo: bind key:[%s] failed, value:[%v] source type:[%v] target type:[%v], cause:[%v]",
+		fe.Key, fe.Value, fe.SourceType, fe.TargetType, fe.Err)
+}
+
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// ----------------------------------------------------------------
+
+// BindError aggregates all the FieldError of one Bind action.
+type BindError struct {
+	Errors []*FieldError
+}
+
+func (be *BindError) Error() string {
+	messages := make([]string, 0, len(be.Errors))
+	for _, fe := range be.Errors {
+		messages = append(messages, fe.Error())
+	}
+
+	return fmt.Sprintf("nemo: bind failed, %d field error(s):[%s]", len(be.Errors), stringz.Join(messageSeparator, messages...))
+}
+
+// Keys returns the keys of all failed fields.
+func (be *BindError) Keys() []string {
+	keys := make([]string, 0, len(be.Errors))
+	for _, fe := range be.Errors {
+		keys = append(keys, fe.Key)
+	}
+
+	return keys
+}
+
+// MissingKeys returns the absent required keys.
+func (be *BindError) MissingKeys() []string {
+	keys := make([]string, 0, len(be.Errors))
+	for _, fe := range be.Errors {
+		if errors.Is(fe, ErrMissingRequired) {
+			keys = append(keys, fe.Key)
+		}
+	}
+
+	return keys
+}
+
+func (be *BindError) add(fe *FieldError) {
+	be.Errors = append(be.Errors, fe)
+}
+
+func (be *BindError) errorOrNil() error {
+	if len(be.Errors) == 0 {
+		return nil
+	}
+
+	return be
+}
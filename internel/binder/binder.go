@@ -17,7 +17,9 @@
 package binder
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/photowey/nemo/pkg/collection"
@@ -25,10 +27,62 @@ import (
 	"github.com/photowey/nemo/pkg/stringz"
 )
 
+// `binder:"name"` | `binder:"name,required"` | `binder:"name,default=value"`
+//
+// the `default` option must be the last one, the rest of the tag is the default value, e.g.: `binder:"hosts,default=a,b"`
+
 const (
-	binderTag = "binder"
+	binderTag         = "binder"
+	ignoreTag         = "-"
+	tagSeparator      = ","
+	requiredTagOption = "required"
+	defaultTagOption  = "default="
 )
 
+type fieldTag struct {
+	name         string
+	required     bool
+	hasDefault   bool
+	defaultValue string
+}
+
+func parseTag(tag string) fieldTag {
+	options := strings.Split(tag, tagSeparator)
+	ft := fieldTag{
+		name: strings.TrimSpace(options[0]),
+	}
+
+	for i := 1; i < len(options); i++ {
+		option := strings.TrimSpace(options[i])
+		switch {
+		case requiredTagOption == option:
+			ft.required = true
+		case strings.HasPrefix(option, defaultTagOption):
+			ft.hasDefault = true
+			rest := strings.TrimSpace(stringz.Join(tagSeparator, options[i:]...))
+			ft.defaultValue = strings.TrimPrefix(rest, defaultTagOption)
+
+			return ft
+		}
+	}
+
+	return ft
+}
+
+// ----------------------------------------------------------------
+
+type bindContext struct {
+	errs *BindError
+}
+
+func newBindContext() *bindContext {
+	return &bindContext{
+		errs: &BindError{},
+	}
+}
+
+// ----------------------------------------------------------------
+
 type Binder struct {
 	Prefix string
 }
@@ -41,33 +95,348 @@ func NewBinder(prefix string) *Binder {
 	return &Binder{Prefix: prefix}
 }
 
-func (b *Binder) DefaultBind(target any, ctx collection.MixedMap) {
-	b.Bind(b.Prefix, target, ctx)
+func (b *Binder) DefaultBind(target any, ctx collection.MixedMap) error {
+	return b.Bind(b.Prefix, target, ctx)
 }
 
-func (b *Binder) Bind(prefix string, target any, ctx collection.MixedMap) {
-	if stringz.IsNotBlankString(prefix) && stringz.IsNotSuffix(prefix, stringz.Dot) {
-		prefix += stringz.Dot
+func (b *Binder) Bind(prefix string, target any, ctx collection.MixedMap) error {
+	tv := reflect.ValueOf(target)
+	if tv.Kind() != reflect.Ptr || tv.IsNil() {
+		return fmt.Errorf("%w, but got:[%T]", ErrInvalidTarget, target)
 	}
 
-	tt := reflect.TypeOf(target).Elem()
-	tv := reflect.ValueOf(target).Elem()
+	prefix = strings.TrimSuffix(prefix, stringz.Dot)
+	bc := newBindContext()
+
+	source := ctx
+	if stringz.IsNotBlankString(prefix) {
+		value, ok := relaxedGet(ctx, prefix)
+		if !ok {
+			// the defaults and the required fields still take effect.
+			value = make(collection.MixedMap)
+		}
+		if source, ok = toMixedMap(value); !ok {
+			bc.errs.add(newFieldError(prefix, value, tv.Elem().Type(), ErrNotConvertible))
+
+			return bc.errs
+		}
+	}
+
+	b.bindValue(prefix, source, tv.Elem(), bc)
+
+	return bc.errs.errorOrNil()
+}
+
+// Convert converts the value of the key into the target by the same rules of the Bind, e.g.: "8080" -> int | "5s" -> time.Duration,
+// the failure is a *FieldError, or a *BindError of the failed elements.
+func (b *Binder) Convert(key string, value any, target any) error {
+	tv := reflect.ValueOf(target)
+	if tv.Kind() != reflect.Ptr || tv.IsNil() {
+		return fmt.Errorf("%w, but got:[%T]", ErrInvalidTarget, target)
+	}
+
+	bc := newBindContext()
+	b.bindValue(key, value, tv.Elem(), bc)
+	if len(bc.errs.Errors) == 1 {
+		return bc.errs.Errors[0]
+	}
+
+	return bc.errs.errorOrNil()
+}
+
+// ----------------------------------------------------------------
+
+func (b *Binder) bindStruct(prefix string, source collection.MixedMap, tv reflect.Value, bc *bindContext) {
+	tt := tv.Type()
 
 	for i := 0; i < tt.NumField(); i++ {
 		t := tt.Field(i)
 		v := tv.Field(i)
 
-		tag := t.Tag.Get(binderTag)
-		key := stringz.Concat(prefix, strings.ToLower(tag))
-
-		if t.Type.Kind() == reflect.Struct {
-			sub := reflect.New(t.Type).Interface()
-			b.Bind(key, sub, ctx)
-			v.Set(reflect.ValueOf(sub).Elem())
-		} else {
-			if value, ok := mapz.NestedGet(ctx, key); ok {
-				v.Set(reflect.ValueOf(value).Convert(t.Type))
-			}
+		if !t.IsExported() {
+			continue
 		}
+
+		rawTag, tagged := t.Tag.Lookup(binderTag)
+		if t.Anonymous && !tagged && isNestedStruct(t.Type) {
+			// embedded struct: the fields are promoted
+			b.bindStruct(prefix, source, v, bc)
+			continue
+		}
+
+		tag := parseTag(rawTag)
+		if ignoreTag == tag.name {
+			continue
+		}
+		if stringz.IsBlankString(tag.name) {
+			tag.name = kebabName(t.Name)
+		}
+
+		key := joinKey(prefix, tag.name)
+
+		// the precedence is decided by the merged source stack, e.g.: the mapped env vars of the os.env source
+		value, ok := relaxedGet(source, tag.name)
+		if !ok || value == nil {
+			b.bindMissing(key, tag, v, bc)
+			continue
+		}
+
+		b.bindValue(key, value, v, bc)
+	}
+}
+
+// bindMissing falls back to the default value when the key is absent,
+// or collects the absent required key.
+func (b *Binder) bindMissing(key string, tag fieldTag, target reflect.Value, bc *bindContext) {
+	switch {
+	case tag.hasDefault:
+		b.bindValue(key, tag.defaultValue, target, bc)
+	case tag.required:
+		bc.errs.add(newFieldError(key, nil, target.Type(), ErrMissingRequired))
+	case isNestedStruct(target.Type()):
+		// the nested defaults and required fields
+		b.bindStruct(key, make(collection.MixedMap), target, bc)
+	}
+}
+
+// bindValue binds the value into the target element by element, the key is the full path of the value,
+// every failure is collected into the bind context and the binding goes on.
+func (b *Binder) bindValue(key string, value any, target reflect.Value, bc *bindContext) {
+	if value == nil {
+		return
+	}
+
+	tt := target.Type()
+	if reflect.TypeOf(value).AssignableTo(tt) {
+		target.Set(reflect.ValueOf(value))
+		return
+	}
+
+	if converter, ok := LookupConverter(tt); ok {
+		b.bindConverted(key, value, target, converter, bc)
+		return
+	}
+
+	if _, ok := value.(string); ok && isTextUnmarshaler(tt) {
+		b.bindText(key, value, target, bc)
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		b.bindPointer(key, value, target, bc)
+	case reflect.Interface:
+		b.bindInterface(key, value, target, bc)
+	case reflect.Struct:
+		b.bindNestedStruct(key, value, target, bc)
+	case reflect.Slice:
+		b.bindSlice(key, value, target, bc)
+	case reflect.Array:
+		b.bindArray(key, value, target, bc)
+	case reflect.Map:
+		b.bindMap(key, value, target, bc)
+	default:
+		b.bindScalar(key, value, target, bc)
+	}
+}
+
+func (b *Binder) bindConverted(key string, value any, target reflect.Value, converter Converter, bc *bindContext) {
+	tt := target.Type()
+
+	converted, err := converter(value, tt)
+	if err != nil {
+		bc.errs.add(newFieldError(key, value, tt, err))
+		return
 	}
+
+	cv := reflect.ValueOf(converted)
+	switch {
+	case converted == nil:
+		target.Set(reflect.Zero(tt))
+	case cv.Type().AssignableTo(tt):
+		target.Set(cv)
+	case cv.Type().ConvertibleTo(tt):
+		target.Set(cv.Convert(tt))
+	default:
+		bc.errs.add(newFieldError(key, value, tt, fmt.Errorf("the converter returns:[%T]", converted)))
+	}
+}
+
+func (b *Binder) bindText(key string, value any, target reflect.Value, bc *bindContext) {
+	tv, err := unmarshalText(value, target.Type())
+	if err != nil {
+		bc.errs.add(newFieldError(key, value, target.Type(), err))
+		return
+	}
+
+	target.Set(tv)
+}
+
+func (b *Binder) bindPointer(key string, value any, target reflect.Value, bc *bindContext) {
+	elem := reflect.New(target.Type().Elem())
+	if !target.IsNil() {
+		elem.Elem().Set(target.Elem())
+	}
+
+	size := len(bc.errs.Errors)
+	b.bindValue(key, value, elem.Elem(), bc)
+	if len(bc.errs.Errors) > size {
+		return
+	}
+
+	target.Set(elem)
+}
+
+func (b *Binder) bindInterface(key string, value any, target reflect.Value, bc *bindContext) {
+	sv := reflect.ValueOf(value)
+	if !sv.Type().AssignableTo(target.Type()) {
+		bc.errs.add(newFieldError(key, value, target.Type(), ErrNotConvertible))
+		return
+	}
+
+	target.Set(sv)
+}
+
+func (b *Binder) bindNestedStruct(key string, value any, target reflect.Value, bc *bindContext) {
+	source, ok := toMixedMap(value)
+	if !ok {
+		bc.errs.add(newFieldError(key, value, target.Type(), ErrNotConvertible))
+		return
+	}
+
+	b.bindStruct(key, source, target, bc)
+}
+
+func (b *Binder) bindSlice(key string, value any, target reflect.Value, bc *bindContext) {
+	if str, ok := value.(string); ok {
+		if target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes([]byte(str))
+			return
+		}
+
+		value = splitList(str)
+	}
+
+	sv := reflect.ValueOf(value)
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		bc.errs.add(newFieldError(key, value, target.Type(), ErrNotConvertible))
+		return
+	}
+
+	slice := reflect.MakeSlice(target.Type(), sv.Len(), sv.Len())
+	for i := 0; i < sv.Len(); i++ {
+		b.bindValue(indexKey(key, i), sv.Index(i).Interface(), slice.Index(i), bc)
+	}
+
+	target.Set(slice)
+}
+
+func (b *Binder) bindArray(key string, value any, target reflect.Value, bc *bindContext) {
+	if str, ok := value.(string); ok {
+		value = splitList(str)
+	}
+
+	sv := reflect.ValueOf(value)
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		bc.errs.add(newFieldError(key, value, target.Type(), ErrNotConvertible))
+		return
+	}
+
+	if sv.Len() > target.Len() {
+		bc.errs.add(newFieldError(key, value, target.Type(), fmt.Errorf("%d elements overflow the length %d", sv.Len(), target.Len())))
+		return
+	}
+
+	array := reflect.New(target.Type()).Elem()
+	for i := 0; i < sv.Len(); i++ {
+		b.bindValue(indexKey(key, i), sv.Index(i).Interface(), array.Index(i), bc)
+	}
+
+	target.Set(array)
+}
+
+func (b *Binder) bindMap(key string, value any, target reflect.Value, bc *bindContext) {
+	tt := target.Type()
+	if tt.Key().Kind() != reflect.String {
+		bc.errs.add(newFieldError(key, value, tt, fmt.Errorf("the key type of map must be string")))
+		return
+	}
+
+	source, ok := toMixedMap(value)
+	if !ok {
+		bc.errs.add(newFieldError(key, value, tt, ErrNotConvertible))
+		return
+	}
+
+	ctx := reflect.MakeMapWithSize(tt, len(source))
+	for _, k := range mapz.SortedKeys(source) {
+		elem := reflect.New(tt.Elem()).Elem()
+		b.bindValue(joinKey(key, k), source[k], elem, bc)
+
+		ctx.SetMapIndex(reflect.ValueOf(k).Convert(tt.Key()), elem)
+	}
+
+	target.Set(ctx)
+}
+
+func (b *Binder) bindScalar(key string, value any, target reflect.Value, bc *bindContext) {
+	sv, err := convertScalar(value, target.Type())
+	if err != nil {
+		bc.errs.add(newFieldError(key, value, target.Type(), err))
+		return
+	}
+
+	target.Set(sv)
+}
+
+// ----------------------------------------------------------------
+
+func isNestedStruct(target reflect.Type) bool {
+	return target.Kind() == reflect.Struct && !ContainsConverter(target) && !isTextUnmarshaler(target)
+}
+
+// splitList splits the comma-separated list, e.g.: a, b, c -> [a b c]
+func splitList(str string) []string {
+	if stringz.IsBlankString(str) {
+		return make([]string, 0)
+	}
+
+	items := strings.Split(str, stringz.SymbolComma)
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+
+	return items
+}
+
+// toMixedMap converts the map[string]any | map[any]any ... into map[string]any.
+func toMixedMap(value any) (collection.MixedMap, bool) {
+	if ctx, ok := value.(collection.MixedMap); ok {
+		return ctx, true
+	}
+
+	sv := reflect.ValueOf(value)
+	if sv.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	ctx := make(collection.MixedMap, sv.Len())
+	iter := sv.MapRange()
+	for iter.Next() {
+		ctx[fmt.Sprintf("%v", iter.Key().Interface())] = iter.Value().Interface()
+	}
+
+	return ctx, true
+}
+
+func joinKey(prefix, name string) string {
+	if stringz.IsBlankString(prefix) {
+		return name
+	}
+
+	return stringz.Join(stringz.Dot, prefix, name)
+}
+
+func indexKey(key string, index int) string {
+	return stringz.Concat(key, "[", strconv.Itoa(index), "]")
 }
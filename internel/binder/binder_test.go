@@ -17,8 +17,11 @@
 package binder
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/photowey/nemo/pkg/collection"
 )
@@ -182,3 +185,362 @@ func TestNewBinder(t *testing.T) {
 		})
 	}
 }
+
+type Server struct {
+	Host string `binder:"host"`
+	Port int    `binder:"port"`
+}
+
+type Containers struct {
+	Hosts    []string          `binder:"hosts"`
+	Ports    [2]int            `binder:"ports"`
+	Weights  map[string]int    `binder:"weights"`
+	Labels   map[string]string `binder:"labels"`
+	Primary  *Server           `binder:"primary"`
+	Servers  []Server          `binder:"servers"`
+	Clusters map[string]Server `binder:"clusters"`
+	Matrix   [][]int           `binder:"matrix"`
+	Extra    any               `binder:"extra"`
+	Timeout  *int              `binder:"timeout"`
+}
+
+func TestBinder_Bind_Containers(t *testing.T) {
+	timeout := 30
+
+	type args struct {
+		prefix string
+		ctx    collection.MixedMap
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Containers
+		wantErr string
+	}{
+		{
+			name: "builder#Bind_containers",
+			args: args{
+				prefix: "app",
+				ctx: collection.MixedMap{
+					"app": collection.MixedMap{
+						"hosts":   []any{"a.com", "b.com"},
+						"ports":   []any{80, 443},
+						"weights": collection.MixedMap{"a": 1, "b": int64(2)},
+						"labels":  map[any]any{"env": "dev"},
+						"primary": collection.MixedMap{"host": "a.com", "port": 80},
+						"servers": []any{
+							collection.MixedMap{"host": "a.com", "port": 80},
+							map[any]any{"host": "b.com", "port": 443},
+						},
+						"clusters": collection.MixedMap{
+							"east": collection.MixedMap{"host": "east.com", "port": 8080},
+						},
+						"matrix":  []any{[]any{1, 2}, []int{3}},
+						"extra":   collection.MixedMap{"k": "v"},
+						"timeout": 30,
+					},
+				},
+			},
+			want: Containers{
+				Hosts:    []string{"a.com", "b.com"},
+				Ports:    [2]int{80, 443},
+				Weights:  map[string]int{"a": 1, "b": 2},
+				Labels:   map[string]string{"env": "dev"},
+				Primary:  &Server{Host: "a.com", Port: 80},
+				Servers:  []Server{{Host: "a.com", Port: 80}, {Host: "b.com", Port: 443}},
+				Clusters: map[string]Server{"east": {Host: "east.com", Port: 8080}},
+				Matrix:   [][]int{{1, 2}, {3}},
+				Extra:    collection.MixedMap{"k": "v"},
+				Timeout:  &timeout,
+			},
+		},
+		{
+			name: "builder#Bind_containers_element_failed",
+			args: args{
+				prefix: "app",
+				ctx: collection.MixedMap{
+					"app": collection.MixedMap{
+						"servers": []any{
+							collection.MixedMap{"host": "a.com", "port": 80},
+							collection.MixedMap{"host": "b.com", "port": []any{443}},
+						},
+					},
+				},
+			},
+			wantErr: "app.servers[1].port",
+		},
+		{
+			name: "builder#Bind_containers_array_overflow",
+			args: args{
+				prefix: "app",
+				ctx: collection.MixedMap{
+					"app": collection.MixedMap{
+						"ports": []any{80, 443, 8080},
+					},
+				},
+			},
+			wantErr: "app.ports",
+		},
+		{
+			name: "builder#Bind_containers_map_element_failed",
+			args: args{
+				prefix: "app",
+				ctx: collection.MixedMap{
+					"app": collection.MixedMap{
+						"weights": collection.MixedMap{"a": true},
+					},
+				},
+			},
+			wantErr: "app.weights.a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Containers{}
+			err := New().Bind(tt.args.prefix, &got, tt.args.ctx)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Bind() error = %v, want key %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, but got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBinder_Bind_Errors(t *testing.T) {
+	ctx := collection.MixedMap{
+		"app": collection.MixedMap{
+			"host":    []any{"a.com"},
+			"port":    true,
+			"servers": []any{collection.MixedMap{"host": "a.com", "port": "x"}},
+		},
+	}
+
+	type App struct {
+		Host    string   `binder:"host"`
+		Port    int      `binder:"port"`
+		Servers []Server `binder:"servers"`
+	}
+
+	got := App{}
+	err := New().Bind("app", &got, ctx)
+
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("Bind() error = %v, want *BindError", err)
+	}
+
+	wantKeys := []string{"app.host", "app.port", "app.servers[0].port"}
+	if !reflect.DeepEqual(bindErr.Keys(), wantKeys) {
+		t.Errorf("BindError.Keys() = %v, want %v", bindErr.Keys(), wantKeys)
+	}
+
+	fe := bindErr.Errors[1]
+	if fe.Value != true || fe.SourceType != reflect.TypeOf(true) || fe.TargetType != reflect.TypeOf(0) {
+		t.Errorf("FieldError = %+v, want value:[true] source:[bool] target:[int]", fe)
+	}
+	if !errors.Is(fe, ErrNotConvertible) {
+		t.Errorf("FieldError.Unwrap() = %v, want %v", fe.Err, ErrNotConvertible)
+	}
+
+	if got.Servers[0].Host != "a.com" {
+		t.Errorf("Bind() should go on after failures, got %+v", got)
+	}
+}
+
+func TestBinder_Convert(t *testing.T) {
+	var port int
+	if err := New().Convert("server.port", "8080", &port); err != nil || port != 8080 {
+		t.Errorf("Convert() got = %v, error = %v", port, err)
+	}
+
+	var timeout time.Duration
+	if err := New().Convert("server.timeout", "5s", &timeout); err != nil || timeout != 5*time.Second {
+		t.Errorf("Convert() got = %v, error = %v", timeout, err)
+	}
+
+	var fe *FieldError
+	if err := New().Convert("server.port", "x", &port); !errors.As(err, &fe) || fe.Key != "server.port" {
+		t.Errorf("Convert() error = %v, want *FieldError", err)
+	}
+
+	var bindErr *BindError
+	var ports []int
+	if err := New().Convert("server.ports", []any{"x", "y"}, &ports); !errors.As(err, &bindErr) || len(bindErr.Errors) != 2 {
+		t.Errorf("Convert() error = %v, want *BindError", err)
+	}
+
+	if err := New().Convert("server.port", "8080", port); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Convert() error = %v, want %v", err, ErrInvalidTarget)
+	}
+}
+
+func TestBinder_Bind_InvalidTarget(t *testing.T) {
+	var nilTarget *Main
+
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{name: "builder#Bind_nil", target: nil},
+		{name: "builder#Bind_nil_pointer", target: nilTarget},
+		{name: "builder#Bind_non_pointer", target: Main{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New().Bind("a", tt.target, collection.MixedMap{}); !errors.Is(err, ErrInvalidTarget) {
+				t.Errorf("Bind() error = %v, want %v", err, ErrInvalidTarget)
+			}
+		})
+	}
+}
+
+type DataSource struct {
+	DSN      string        `binder:"dsn,required"`
+	Username string        `binder:"username,required"`
+	Port     int           `binder:"port,default=3306"`
+	Hosts    []string      `binder:"hosts,default=a.com, b.com"`
+	Timeout  time.Duration `binder:"timeout, default=30s"`
+	Pool     Pool          `binder:"pool"`
+}
+
+type Pool struct {
+	Size    int `binder:"size,default=10"`
+	MaxIdle int `binder:"max-idle,required"`
+}
+
+func TestBinder_Bind_Defaults(t *testing.T) {
+	ctx := collection.MixedMap{
+		"datasource": collection.MixedMap{
+			"dsn":      "mysql://localhost",
+			"username": "root",
+			"port":     3307,
+			"pool": collection.MixedMap{
+				"max-idle": 5,
+			},
+		},
+	}
+
+	got := DataSource{}
+	if err := New().Bind("datasource", &got, ctx); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	want := DataSource{
+		DSN:      "mysql://localhost",
+		Username: "root",
+		Port:     3307,
+		Hosts:    []string{"a.com", "b.com"},
+		Timeout:  30 * time.Second,
+		Pool:     Pool{Size: 10, MaxIdle: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, but got %+v", want, got)
+	}
+}
+
+func TestBinder_Bind_Required(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  collection.MixedMap
+		want []string
+	}{
+		{
+			name: "builder#Bind_required_partial",
+			ctx: collection.MixedMap{
+				"datasource": collection.MixedMap{
+					"username": "root",
+					"pool":     collection.MixedMap{},
+				},
+			},
+			want: []string{"datasource.dsn", "datasource.pool.max-idle"},
+		},
+		{
+			name: "builder#Bind_required_prefix_absent",
+			ctx:  collection.MixedMap{},
+			want: []string{"datasource.dsn", "datasource.username", "datasource.pool.max-idle"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DataSource{}
+			err := New().Bind("datasource", &got, tt.ctx)
+
+			var bindErr *BindError
+			if !errors.As(err, &bindErr) {
+				t.Fatalf("Bind() error = %v, want *BindError", err)
+			}
+			if !reflect.DeepEqual(bindErr.MissingKeys(), tt.want) {
+				t.Errorf("BindError.MissingKeys() = %v, want %v", bindErr.MissingKeys(), tt.want)
+			}
+			if got.Port != 3306 || got.Pool.Size != 10 {
+				t.Errorf("Bind() defaults not applied, got %+v", got)
+			}
+		})
+	}
+}
+
+type Common struct {
+	Name string
+}
+
+type RelaxedServer struct {
+	Common
+	MaxConnections int           `binder:"max-connections"`
+	IdleTimeout    time.Duration `binder:"idleTimeout"`
+	Port           int
+	DBHost         string
+	Ignored        string `binder:"-"`
+}
+
+func TestBinder_Bind_Relaxed(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  collection.MixedMap
+		want RelaxedServer
+	}{
+		{
+			name: "builder#Bind_relaxed_camel",
+			ctx: collection.MixedMap{
+				"server": collection.MixedMap{
+					"name":           "nemo",
+					"maxConnections": 10,
+					"idle-timeout":   "1m",
+					"port":           8080,
+					"dbHost":         "localhost",
+					"ignored":        "x",
+				},
+			},
+			want: RelaxedServer{Common: Common{Name: "nemo"}, MaxConnections: 10, IdleTimeout: time.Minute, Port: 8080, DBHost: "localhost"},
+		},
+		{
+			name: "builder#Bind_relaxed_snake",
+			ctx: collection.MixedMap{
+				"Server": collection.MixedMap{
+					"max_connections": 20,
+					"idle_timeout":    "2m",
+					"db_host":         "127.0.0.1",
+				},
+			},
+			want: RelaxedServer{MaxConnections: 20, IdleTimeout: 2 * time.Minute, DBHost: "127.0.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RelaxedServer{}
+			if err := New().Bind("server", &got, tt.ctx); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, but got %+v", tt.want, got)
+			}
+		})
+	}
+}
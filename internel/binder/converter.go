@@ -0,0 +1,438 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dateTimeLayout = "2006-01-02 15:04:05"
+	dateLayout     = "2006-01-02"
+)
+
+// Converter converts the source value into the value of the target type,
+// the result must be assignable or convertible to the target type.
+type Converter func(value any, target reflect.Type) (any, error)
+
+var (
+	_ ConverterRegistry = (*converterRegistry)(nil)
+
+	_converterRegistry = &converterRegistry{
+		converterMap: make(map[reflect.Type]Converter, 1<<3),
+		timeLayouts: []string{
+			time.RFC3339Nano,
+			time.RFC3339,
+			dateTimeLayout,
+			dateLayout,
+		},
+	}
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+var (
+	trueValues  = []string{"true", "yes", "on", "1"}
+	falseValues = []string{"false", "no", "off", "0"}
+)
+
+func init() {
+	RegisterConverter(reflect.TypeOf(time.Duration(0)), durationConverter)
+	RegisterConverter(reflect.TypeOf(time.Time{}), timeConverter)
+	RegisterConverter(reflect.TypeOf(DataSize(0)), dataSizeConverter)
+	RegisterConverter(reflect.TypeOf(net.IP{}), ipConverter)
+	RegisterConverter(reflect.TypeOf(url.URL{}), urlConverter)
+	RegisterConverter(reflect.TypeOf(&url.URL{}), urlConverter)
+}
+
+// ----------------------------------------------------------------
+
+type ConverterRegistry interface {
+	Register(target reflect.Type, converter Converter)
+	Contains(target reflect.Type) bool
+	Converter(target reflect.Type) (Converter, bool)
+	RegisterTimeLayouts(layouts ...string)
+	TimeLayouts() []string
+}
+
+type converterRegistry struct {
+	sync.RWMutex
+	converterMap map[reflect.Type]Converter
+	timeLayouts  []string
+}
+
+func (r *converterRegistry) Register(target reflect.Type, converter Converter) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.converterMap[target] = converter
+}
+
+func (r *converterRegistry) Contains(target reflect.Type) bool {
+	_, ok := r.Converter(target)
+
+	return ok
+}
+
+func (r *converterRegistry) Converter(target reflect.Type) (Converter, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	v, ok := r.converterMap[target]
+
+	return v, ok
+}
+
+func (r *converterRegistry) RegisterTimeLayouts(layouts ...string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.timeLayouts = append(r.timeLayouts, layouts...)
+}
+
+func (r *converterRegistry) TimeLayouts() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	layouts := make([]string, len(r.timeLayouts))
+	copy(layouts, r.timeLayouts)
+
+	return layouts
+}
+
+// ----------------------------------------------------------------
+
+// RegisterConverter registers the Converter of the target type, replacing the built-in one if any.
+func RegisterConverter(target reflect.Type, converter Converter) {
+	_converterRegistry.Register(target, converter)
+}
+
+func ContainsConverter(target reflect.Type) bool {
+	return _converterRegistry.Contains(target)
+}
+
+func LookupConverter(target reflect.Type) (Converter, bool) {
+	return _converterRegistry.Converter(target)
+}
+
+// RegisterTimeLayouts appends the layouts tried in order when converting a string into time.Time.
+func RegisterTimeLayouts(layouts ...string) {
+	_converterRegistry.RegisterTimeLayouts(layouts...)
+}
+
+func TimeLayouts() []string {
+	return _converterRegistry.TimeLayouts()
+}
+
+// ---------------------------------------------------------------- built-in
+
+func durationConverter(value any, _ reflect.Type) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return time.ParseDuration(strings.TrimSpace(v))
+	default:
+		n, err := toInt64(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return time.Duration(n), nil
+	}
+}
+
+func timeConverter(value any, _ reflect.Type) (any, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, ErrNotConvertible
+	}
+
+	str = strings.TrimSpace(str)
+	for _, layout := range TimeLayouts() {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("time:[%s] matches none of the layouts %v", str, TimeLayouts())
+}
+
+func dataSizeConverter(value any, _ reflect.Type) (any, error) {
+	if str, ok := value.(string); ok {
+		return ParseDataSize(str)
+	}
+
+	n, err := toInt64(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return DataSize(n), nil
+}
+
+func ipConverter(value any, _ reflect.Type) (any, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, ErrNotConvertible
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(str))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip:[%s]", str)
+	}
+
+	return ip, nil
+}
+
+func urlConverter(value any, target reflect.Type) (any, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, ErrNotConvertible
+	}
+
+	u, err := url.Parse(strings.TrimSpace(str))
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Kind() == reflect.Ptr {
+		return u, nil
+	}
+
+	return *u, nil
+}
+
+// ----------------------------------------------------------------
+
+func isTextUnmarshaler(target reflect.Type) bool {
+	return reflect.PtrTo(target).Implements(textUnmarshalerType)
+}
+
+func unmarshalText(value any, target reflect.Type) (reflect.Value, error) {
+	str, ok := value.(string)
+	if !ok {
+		return reflect.Value{}, ErrNotConvertible
+	}
+
+	ptr := reflect.New(target)
+	if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return ptr.Elem(), nil
+}
+
+// convertScalar converts the string | bool | number value into the scalar target type, with overflow checks.
+func convertScalar(value any, target reflect.Type) (reflect.Value, error) {
+	rv := reflect.New(target).Elem()
+
+	switch target.Kind() {
+	case reflect.String:
+		str, err := toString(value)
+		if err != nil {
+			return rv, err
+		}
+		rv.SetString(str)
+	case reflect.Bool:
+		b, err := toBool(value)
+		if err != nil {
+			return rv, err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(value)
+		if err != nil {
+			return rv, err
+		}
+		if rv.OverflowInt(n) {
+			return rv, fmt.Errorf("%d overflows %s", n, target)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := toUint64(value)
+		if err != nil {
+			return rv, err
+		}
+		if rv.OverflowUint(n) {
+			return rv, fmt.Errorf("%d overflows %s", n, target)
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(value)
+		if err != nil {
+			return rv, err
+		}
+		if rv.OverflowFloat(f) {
+			return rv, fmt.Errorf("%v overflows %s", f, target)
+		}
+		rv.SetFloat(f)
+	default:
+		return rv, ErrNotConvertible
+	}
+
+	return rv, nil
+}
+
+func toString(value any) (string, error) {
+	sv := reflect.ValueOf(value)
+
+	switch {
+	case sv.Kind() == reflect.String:
+		return sv.String(), nil
+	case sv.Kind() == reflect.Bool:
+		return strconv.FormatBool(sv.Bool()), nil
+	case isIntKind(sv.Kind()):
+		return strconv.FormatInt(sv.Int(), 10), nil
+	case isUintKind(sv.Kind()):
+		return strconv.FormatUint(sv.Uint(), 10), nil
+	case isFloatKind(sv.Kind()):
+		return strconv.FormatFloat(sv.Float(), 'f', -1, 64), nil
+	default:
+		return "", ErrNotConvertible
+	}
+}
+
+func toBool(value any) (bool, error) {
+	sv := reflect.ValueOf(value)
+
+	switch {
+	case sv.Kind() == reflect.Bool:
+		return sv.Bool(), nil
+	case sv.Kind() == reflect.String:
+		return parseBool(sv.String())
+	case isIntKind(sv.Kind()) || isUintKind(sv.Kind()):
+		str, _ := toString(value)
+		return parseBool(str)
+	default:
+		return false, ErrNotConvertible
+	}
+}
+
+func parseBool(str string) (bool, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+
+	for _, v := range trueValues {
+		if v == str {
+			return true, nil
+		}
+	}
+
+	for _, v := range falseValues {
+		if v == str {
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("invalid bool:[%s]", str)
+}
+
+func toInt64(value any) (int64, error) {
+	sv := reflect.ValueOf(value)
+
+	switch {
+	case sv.Kind() == reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(sv.String()), 10, 64)
+	case isIntKind(sv.Kind()):
+		return sv.Int(), nil
+	case isUintKind(sv.Kind()):
+		if sv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows int64", sv.Uint())
+		}
+		return int64(sv.Uint()), nil
+	case isFloatKind(sv.Kind()):
+		f := sv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an int64", f)
+		}
+		return int64(f), nil
+	default:
+		return 0, ErrNotConvertible
+	}
+}
+
+func toUint64(value any) (uint64, error) {
+	sv := reflect.ValueOf(value)
+
+	switch {
+	case sv.Kind() == reflect.String:
+		return strconv.ParseUint(strings.TrimSpace(sv.String()), 10, 64)
+	case isIntKind(sv.Kind()):
+		if sv.Int() < 0 {
+			return 0, fmt.Errorf("%d overflows uint64", sv.Int())
+		}
+		return uint64(sv.Int()), nil
+	case isUintKind(sv.Kind()):
+		return sv.Uint(), nil
+	case isFloatKind(sv.Kind()):
+		f := sv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is not an uint64", f)
+		}
+		return uint64(f), nil
+	default:
+		return 0, ErrNotConvertible
+	}
+}
+
+func toFloat64(value any) (float64, error) {
+	sv := reflect.ValueOf(value)
+
+	switch {
+	case sv.Kind() == reflect.String:
+		return strconv.ParseFloat(strings.TrimSpace(sv.String()), 64)
+	case isIntKind(sv.Kind()):
+		return float64(sv.Int()), nil
+	case isUintKind(sv.Kind()):
+		return float64(sv.Uint()), nil
+	case isFloatKind(sv.Kind()):
+		return sv.Float(), nil
+	default:
+		return 0, ErrNotConvertible
+	}
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
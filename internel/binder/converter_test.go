@@ -0,0 +1,257 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+
+	return nil
+}
+
+type Version struct {
+	Major int
+	Minor int
+}
+
+type Coercions struct {
+	Int      int            `binder:"int"`
+	Int8     int8           `binder:"int8"`
+	Uint16   uint16         `binder:"uint16"`
+	Float32  float32        `binder:"float32"`
+	Float64  float64        `binder:"float64"`
+	Bool     bool           `binder:"bool"`
+	Yes      bool           `binder:"yes"`
+	Off      bool           `binder:"off"`
+	String   string         `binder:"string"`
+	Timeout  time.Duration  `binder:"timeout"`
+	Created  time.Time      `binder:"created"`
+	Birthday time.Time      `binder:"birthday"`
+	Size     DataSize       `binder:"size"`
+	IP       net.IP         `binder:"ip"`
+	URL      *url.URL       `binder:"url"`
+	Hosts    []string       `binder:"hosts"`
+	Ports    []int          `binder:"ports"`
+	Level    Level          `binder:"level"`
+	Version  Version        `binder:"version"`
+	Interval *time.Duration `binder:"interval"`
+}
+
+func TestBinder_Bind_Coercions(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(Version{}), func(value any, _ reflect.Type) (any, error) {
+		var v Version
+		parts := strings.Split(value.(string), ".")
+		if len(parts) != 2 {
+			return nil, errors.New("invalid version")
+		}
+		major, _ := toInt64(parts[0])
+		minor, _ := toInt64(parts[1])
+		v.Major, v.Minor = int(major), int(minor)
+
+		return v, nil
+	})
+
+	interval := 5 * time.Minute
+	ctx := collection.MixedMap{
+		"int":      "8080",
+		"int8":     int64(127),
+		"uint16":   "016",
+		"float32":  "3.5",
+		"float64":  int64(3),
+		"bool":     "true",
+		"yes":      "YES",
+		"off":      "off",
+		"string":   9527,
+		"timeout":  "30s",
+		"created":  "2023-08-01T10:00:00Z",
+		"birthday": "2023-08-01",
+		"size":     "10MB",
+		"ip":       "192.168.1.10",
+		"url":      "https://github.com/photowey/nemo",
+		"hosts":    "a.com, b.com ,c.com",
+		"ports":    "80,443",
+		"level":    "info",
+		"version":  "1.2",
+		"interval": "5m",
+	}
+
+	got := Coercions{}
+	if err := New().Bind("", &got, ctx); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	u, _ := url.Parse("https://github.com/photowey/nemo")
+	want := Coercions{
+		Int:      8080,
+		Int8:     127,
+		Uint16:   16,
+		Float32:  3.5,
+		Float64:  3,
+		Bool:     true,
+		Yes:      true,
+		Off:      false,
+		String:   "9527",
+		Timeout:  30 * time.Second,
+		Created:  time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC),
+		Birthday: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		Size:     10 * MegaByte,
+		IP:       net.ParseIP("192.168.1.10"),
+		URL:      u,
+		Hosts:    []string{"a.com", "b.com", "c.com"},
+		Ports:    []int{80, 443},
+		Level:    2,
+		Version:  Version{Major: 1, Minor: 2},
+		Interval: &interval,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, but got %+v", want, got)
+	}
+}
+
+func TestBinder_Bind_CoercionErrors(t *testing.T) {
+	ctx := collection.MixedMap{
+		"int":     "eighty",
+		"int8":    128,
+		"uint16":  -1,
+		"float32": 1e40,
+		"bool":    "maybe",
+		"timeout": "30",
+		"created": "yesterday",
+		"size":    "10XB",
+		"ip":      "localhost",
+		"level":   "trace",
+	}
+
+	err := New().Bind("", &Coercions{}, ctx)
+
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("Bind() error = %v, want *BindError", err)
+	}
+
+	want := []string{"int", "int8", "uint16", "float32", "bool", "timeout", "created", "size", "ip", "level"}
+	if !reflect.DeepEqual(bindErr.Keys(), want) {
+		t.Errorf("BindError.Keys() = %v, want %v", bindErr.Keys(), want)
+	}
+}
+
+func TestToInt64_Decimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    int64
+		wantErr bool
+	}{
+		{name: "converter#toInt64_leading_zero", value: "010", want: 10},
+		{name: "converter#toInt64_leading_zero_8", value: "08", want: 8},
+		{name: "converter#toInt64_negative", value: "-007", want: -7},
+		{name: "converter#toInt64_hex", value: "0x10", wantErr: true},
+		{name: "converter#toInt64_underscore", value: "1_000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toInt64(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toInt64() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("toInt64() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, err := toUint64("010"); err != nil || got != 10 {
+		t.Errorf("toUint64() got = %v, error = %v, want %v", got, err, 10)
+	}
+	if _, err := toUint64("0x10"); err == nil {
+		t.Errorf("toUint64() error = nil, want the invalid syntax error")
+	}
+}
+
+func TestRegisterTimeLayouts(t *testing.T) {
+	RegisterTimeLayouts("2006/01/02")
+
+	got := struct {
+		Day time.Time `binder:"day"`
+	}{}
+	if err := New().Bind("", &got, collection.MixedMap{"day": "2023/08/01"}); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if want := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC); !got.Day.Equal(want) {
+		t.Errorf("Bind() got = %v, want %v", got.Day, want)
+	}
+}
+
+func TestParseDataSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    DataSize
+		wantErr bool
+	}{
+		{name: "datasize#bytes", text: "512", want: 512},
+		{name: "datasize#B", text: "512B", want: 512 * Byte},
+		{name: "datasize#KB", text: "10KB", want: 10 * KiloByte},
+		{name: "datasize#MB_lower", text: "10mb", want: 10 * MegaByte},
+		{name: "datasize#GB_space", text: "1 GB", want: GigaByte},
+		{name: "datasize#TB", text: "2TB", want: 2 * TeraByte},
+		{name: "datasize#invalid", text: "10XB", wantErr: true},
+		{name: "datasize#overflow", text: "9999999TB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDataSize(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDataSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseDataSize() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSize_String(t *testing.T) {
+	if got := (10 * MegaByte).String(); got != "10MB" {
+		t.Errorf("String() got = %v, want %v", got, "10MB")
+	}
+	if got := DataSize(1500).String(); got != "1500B" {
+		t.Errorf("String() got = %v, want %v", got, "1500B")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DataSize | the size of data in bytes, e.g.: 512B | 10KB | 10MB | 1GB | 1TB
+type DataSize int64
+
+const (
+	Byte     DataSize = 1
+	KiloByte          = 1024 * Byte
+	MegaByte          = 1024 * KiloByte
+	GigaByte          = 1024 * MegaByte
+	TeraByte          = 1024 * GigaByte
+)
+
+var (
+	dataSizeUnits = []struct {
+		suffix string
+		unit   DataSize
+	}{
+		// the longer suffix first: KB before B
+		{"TB", TeraByte},
+		{"GB", GigaByte},
+		{"MB", MegaByte},
+		{"KB", KiloByte},
+		{"B", Byte},
+	}
+)
+
+func (ds DataSize) Bytes() int64 {
+	return int64(ds)
+}
+
+func (ds DataSize) String() string {
+	for _, u := range dataSizeUnits {
+		if ds != 0 && ds%u.unit == 0 {
+			return fmt.Sprintf("%d%s", ds/u.unit, u.suffix)
+		}
+	}
+
+	return fmt.Sprintf("%dB", ds.Bytes())
+}
+
+// ParseDataSize parses the size text, the text without unit is treated as bytes.
+func ParseDataSize(text string) (DataSize, error) {
+	str := strings.ToUpper(strings.TrimSpace(text))
+
+	unit := Byte
+	for _, u := range dataSizeUnits {
+		if strings.HasSuffix(str, u.suffix) {
+			unit = u.unit
+			str = strings.TrimSpace(strings.TrimSuffix(str, u.suffix))
+
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid data size:[%s]", text)
+	}
+
+	if size > math.MaxInt64/int64(unit) || size < math.MinInt64/int64(unit) {
+		return 0, fmt.Errorf("data size:[%s] overflows int64", text)
+	}
+
+	return DataSize(size) * unit, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+// relaxed binding:
+//
+// server.max-connections == server.maxConnections == server.max_connections == Server.MaxConnections
+//
+// the env vars are bound by the keys of the os.env source, they're merged into the relaxed keys of the lower sources,
+// e.g.: SERVER_MAX_CONNECTIONS | SERVER_MAXCONNECTIONS | WithEnvPrefix("APP") -> APP_SERVER_MAX_CONNECTIONS -> server.max-connections
+
+const (
+	kebabSeparator = "-"
+)
+
+// CanonicalName lowercases the name and drops the `-` and `_` separators, e.g.: maxConnections -> maxconnections
+func CanonicalName(name string) string {
+	var buf strings.Builder
+	for _, r := range name {
+		if '-' == r || '_' == r {
+			continue
+		}
+		buf.WriteRune(unicode.ToLower(r))
+	}
+
+	return buf.String()
+}
+
+// kebabName converts the Go field name into kebab-case, e.g.: MaxConnections -> max-connections | DBHost -> db-host
+func kebabName(name string) string {
+	runes := []rune(name)
+
+	var buf strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				buf.WriteString(kebabSeparator)
+			}
+		}
+		buf.WriteRune(unicode.ToLower(r))
+	}
+
+	return buf.String()
+}
+
+// relaxedGet finds the value of the dotted key, each segment matches the exact key first, then the canonical one.
+func relaxedGet(ctx collection.MixedMap, key string) (any, bool) {
+	if value, ok := mapz.NestedGet(ctx, key); ok {
+		return value, true
+	}
+
+	segments := strings.Split(key, stringz.Dot)
+	current := ctx
+
+	for i, segment := range segments {
+		value, ok := relaxedSegment(current, segment)
+		if !ok {
+			return nil, false
+		}
+
+		if i == len(segments)-1 {
+			return value, true
+		}
+
+		if current, ok = toMixedMap(value); !ok {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func relaxedSegment(ctx collection.MixedMap, segment string) (any, bool) {
+	if value, ok := ctx[segment]; ok {
+		return value, true
+	}
+
+	canonical := CanonicalName(segment)
+	for _, k := range mapz.SortedKeys(ctx) {
+		if CanonicalName(k) == canonical {
+			return ctx[k], true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package binder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+func TestKebabName(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{name: "relaxed#kebabName_simple", args: "Host", want: "host"},
+		{name: "relaxed#kebabName_camel", args: "MaxConnections", want: "max-connections"},
+		{name: "relaxed#kebabName_acronym", args: "DBHost", want: "db-host"},
+		{name: "relaxed#kebabName_acronym_tail", args: "ServerURL", want: "server-url"},
+		{name: "relaxed#kebabName_digit", args: "Http2Enabled", want: "http2-enabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kebabName(tt.args); got != tt.want {
+				t.Errorf("kebabName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelaxedGet(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    collection.MixedMap
+		key    string
+		want   any
+		wantOk bool
+	}{
+		{
+			name:   "relaxed#relaxedGet_camel",
+			ctx:    collection.MixedMap{"server": collection.MixedMap{"maxConnections": 10}},
+			key:    "server.max-connections",
+			want:   10,
+			wantOk: true,
+		},
+		{
+			name:   "relaxed#relaxedGet_snake",
+			ctx:    collection.MixedMap{"Server": collection.MixedMap{"max_connections": 20}},
+			key:    "server.maxConnections",
+			want:   20,
+			wantOk: true,
+		},
+		{
+			name:   "relaxed#relaxedGet_exact_first",
+			ctx:    collection.MixedMap{"server": collection.MixedMap{"max-connections": 30, "maxConnections": 40}},
+			key:    "server.maxConnections",
+			want:   40,
+			wantOk: true,
+		},
+		{
+			name:   "relaxed#relaxedGet_not_found",
+			ctx:    collection.MixedMap{"server": collection.MixedMap{"port": 8080}},
+			key:    "server.max-connections",
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := relaxedGet(tt.ctx, tt.key)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("relaxedGet() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
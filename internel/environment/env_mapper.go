@@ -0,0 +1,235 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/photowey/nemo/internel/binder"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+// APP_DB_HOST -> db.host | APP_DB__NAME_SUFFIX -> db.name_suffix | APP_SERVERS_0_HOST -> servers[0].host
+
+const (
+	EnvKeySeparator   = "_"
+	EnvMaxListIndex   = 1 << 10
+	envKeySeparatorCh = '_'
+)
+
+// EnvKeyMapper maps the env var name into the segments of the nested key,
+// the numeric segments are the list indices, false means the env var is skipped.
+type EnvKeyMapper func(prefix, name string) (collection.StringSlice, bool)
+
+// DefaultEnvKeyMapper strips the prefix, splits the name on `_` and lowercases the segments, `__` is a literal `_`.
+func DefaultEnvKeyMapper(prefix, name string) (collection.StringSlice, bool) {
+	if stringz.IsNotBlankString(prefix) {
+		envPrefix := strings.ToUpper(strings.TrimSuffix(prefix, EnvKeySeparator)) + EnvKeySeparator
+		if !strings.HasPrefix(strings.ToUpper(name), envPrefix) {
+			return nil, false
+		}
+		name = name[len(envPrefix):]
+	}
+
+	segments := make(collection.StringSlice, 0)
+
+	var buf strings.Builder
+	for i := 0; i < len(name); i++ {
+		if envKeySeparatorCh != name[i] {
+			buf.WriteByte(name[i])
+			continue
+		}
+
+		if i+1 < len(name) && envKeySeparatorCh == name[i+1] {
+			buf.WriteByte(envKeySeparatorCh)
+			i++
+			continue
+		}
+
+		segments = append(segments, strings.ToLower(buf.String()))
+		buf.Reset()
+	}
+	segments = append(segments, strings.ToLower(buf.String()))
+
+	for _, segment := range segments {
+		if stringz.IsBlankString(segment) {
+			return nil, false
+		}
+	}
+
+	return segments, true
+}
+
+// ----------------------------------------------------------------
+
+// nestedSetIndexed sets the value into the container by the segments, creates the list for the numeric segment.
+func nestedSetIndexed(container any, segments collection.StringSlice, value any) any {
+	if len(segments) == 0 {
+		return value
+	}
+
+	segment := segments[0]
+	if index, ok := toListIndex(segment); ok {
+		list, _ := container.([]any)
+		for len(list) <= index {
+			list = append(list, nil)
+		}
+		list[index] = nestedSetIndexed(list[index], segments[1:], value)
+
+		return list
+	}
+
+	ctx, ok := container.(collection.MixedMap)
+	if !ok {
+		ctx = make(collection.MixedMap)
+	}
+	ctx[segment] = nestedSetIndexed(ctx[segment], segments[1:], value)
+
+	return ctx
+}
+
+func toListIndex(segment string) (int, bool) {
+	index, err := strconv.Atoi(segment)
+	if err != nil || index < 0 || index > EnvMaxListIndex {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// ----------------------------------------------------------------
+
+// normalizeEnvVars normalizes the keys of the os.env source against the relaxed keys of the lower sources,
+// so the env vars override the existing keys instead of sitting next to them, e.g.: server.max-connections
+//
+// the raw env var is kept by its name, and overrides the existing leaf it matches: SERVER_MAX_CONNECTIONS | SERVER_MAXCONNECTIONS
+//
+// the mapped env var overrides the existing key it matches, or is added: WithEnvPrefix("APP") -> APP_SERVER_MAX__CONNECTIONS
+func normalizeEnvVars(lower, ctx collection.MixedMap) collection.MixedMap {
+	normalized := make(collection.MixedMap)
+	for _, key := range mapz.SortedKeys(ctx) {
+		value := ctx[key]
+		if mapz.IsMixedMap(value) {
+			continue
+		}
+
+		normalized[key] = value
+		segments, ok := DefaultEnvKeyMapper("", key)
+		if !ok {
+			continue
+		}
+		// the raw env var never replaces the nested map, e.g.: HOME | PATH
+		if path, ok := relaxedPath(lower, segments); ok && !mapz.IsMixedMap(nestedGetPath(lower, path)) {
+			nestedSetPath(normalized, path, value)
+		}
+	}
+
+	// the mapped env vars override the raw ones.
+	for _, key := range mapz.SortedKeys(ctx) {
+		if nested, ok := ctx[key].(collection.MixedMap); ok {
+			normalizeMappedEnvVars(lower, normalized, collection.StringSlice{key}, nested)
+		}
+	}
+
+	return normalized
+}
+
+func normalizeMappedEnvVars(lower, normalized collection.MixedMap, segments collection.StringSlice, ctx collection.MixedMap) {
+	for _, key := range mapz.SortedKeys(ctx) {
+		nestedSegments := append(append(make(collection.StringSlice, 0, len(segments)+1), segments...), key)
+		if nested, ok := ctx[key].(collection.MixedMap); ok {
+			normalizeMappedEnvVars(lower, normalized, nestedSegments, nested)
+			continue
+		}
+
+		if path, ok := relaxedPath(lower, nestedSegments); ok {
+			nestedSegments = path
+		}
+		nestedSetPath(normalized, nestedSegments, mapz.DeepCopy(ctx[key]))
+	}
+}
+
+// relaxedPath finds the existing keys matched by the segments, the adjacent segments may match one key,
+// e.g.: [server max connections] -> [server max-connections]
+func relaxedPath(ctx collection.MixedMap, segments collection.StringSlice) (collection.StringSlice, bool) {
+	for i := 1; i <= len(segments); i++ {
+		key, ok := relaxedKey(ctx, strings.Join(segments[:i], ""))
+		if !ok {
+			continue
+		}
+		if i == len(segments) {
+			return collection.StringSlice{key}, true
+		}
+
+		nested, ok := ctx[key].(collection.MixedMap)
+		if !ok {
+			continue
+		}
+		if path, ok := relaxedPath(nested, segments[i:]); ok {
+			return append(collection.StringSlice{key}, path...), true
+		}
+	}
+
+	return nil, false
+}
+
+// relaxedKey finds the key of the same canonical name, the exact key first.
+func relaxedKey(ctx collection.MixedMap, name string) (string, bool) {
+	if _, ok := ctx[name]; ok {
+		return name, true
+	}
+
+	canonical := binder.CanonicalName(name)
+	for _, key := range mapz.SortedKeys(ctx) {
+		if binder.CanonicalName(key) == canonical {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+func nestedGetPath(ctx collection.MixedMap, path collection.StringSlice) any {
+	var value any = ctx
+	for _, key := range path {
+		current, ok := value.(collection.MixedMap)
+		if !ok {
+			return nil
+		}
+		value = current[key]
+	}
+
+	return value
+}
+
+// nestedSetPath sets the value by the keys, the keys may contain the dots, e.g.: the raw env var names.
+func nestedSetPath(ctx collection.MixedMap, path collection.StringSlice, value any) {
+	current := ctx
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(collection.MixedMap)
+		if !ok {
+			next = make(collection.MixedMap)
+			current[key] = next
+		}
+		current = next
+	}
+
+	current[path[len(path)-1]] = value
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+func TestDefaultEnvKeyMapper(t *testing.T) {
+	type args struct {
+		prefix string
+		name   string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   collection.StringSlice
+		wantOk bool
+	}{
+		{
+			name:   "environment#DefaultEnvKeyMapper_prefix",
+			args:   args{prefix: "APP", name: "APP_DB_HOST"},
+			want:   collection.StringSlice{"db", "host"},
+			wantOk: true,
+		},
+		{
+			name:   "environment#DefaultEnvKeyMapper_prefix_separator",
+			args:   args{prefix: "app_", name: "APP_DB_HOST"},
+			want:   collection.StringSlice{"db", "host"},
+			wantOk: true,
+		},
+		{
+			name:   "environment#DefaultEnvKeyMapper_literal_underscore",
+			args:   args{prefix: "APP", name: "APP_DB__NAME_SUFFIX"},
+			want:   collection.StringSlice{"db_name", "suffix"},
+			wantOk: true,
+		},
+		{
+			name:   "environment#DefaultEnvKeyMapper_index",
+			args:   args{prefix: "APP", name: "APP_SERVERS_0_HOST"},
+			want:   collection.StringSlice{"servers", "0", "host"},
+			wantOk: true,
+		},
+		{
+			name:   "environment#DefaultEnvKeyMapper_other_prefix",
+			args:   args{prefix: "APP", name: "HOME"},
+			wantOk: false,
+		},
+		{
+			name:   "environment#DefaultEnvKeyMapper_empty_segment",
+			args:   args{prefix: "APP", name: "APP_DB_"},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DefaultEnvKeyMapper(tt.args.prefix, tt.args.name)
+			if ok != tt.wantOk {
+				t.Fatalf("DefaultEnvKeyMapper() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultEnvKeyMapper() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNestedSetIndexed(t *testing.T) {
+	ctx := make(collection.MixedMap)
+	nestedSetIndexed(ctx, collection.StringSlice{"db", "host"}, "localhost")
+	nestedSetIndexed(ctx, collection.StringSlice{"servers", "1", "host"}, "b.com")
+	nestedSetIndexed(ctx, collection.StringSlice{"servers", "0", "host"}, "a.com")
+	nestedSetIndexed(ctx, collection.StringSlice{"tags", "0"}, "x")
+
+	want := collection.MixedMap{
+		"db": collection.MixedMap{"host": "localhost"},
+		"servers": []any{
+			collection.MixedMap{"host": "a.com"},
+			collection.MixedMap{"host": "b.com"},
+		},
+		"tags": []any{"x"},
+	}
+	if !reflect.DeepEqual(ctx, want) {
+		t.Errorf("nestedSetIndexed() got = %v, want %v", ctx, want)
+	}
+}
+
+func TestNormalizeEnvVars(t *testing.T) {
+	lower := collection.MixedMap{
+		"server": collection.MixedMap{"max-connections": 10, "idle_timeout": "1m", "db": collection.MixedMap{"host": "127.0.0.1"}},
+		"home":   collection.MixedMap{"dir": "/opt"},
+	}
+
+	tests := []struct {
+		name string
+		ctx  collection.MixedMap
+		want collection.MixedMap
+	}{
+		{
+			name: "environment#normalizeEnvVars_raw",
+			ctx:  collection.MixedMap{"SERVER_MAX_CONNECTIONS": "77", "SERVER_IDLETIMEOUT": "2m", "HOME": "/root"},
+			want: collection.MixedMap{
+				"SERVER_MAX_CONNECTIONS": "77",
+				"SERVER_IDLETIMEOUT":     "2m",
+				"HOME":                   "/root",
+				"server":                 collection.MixedMap{"max-connections": "77", "idle_timeout": "2m"},
+			},
+		},
+		{
+			name: "environment#normalizeEnvVars_mapped_kebab",
+			ctx: collection.MixedMap{
+				"server": collection.MixedMap{"max": collection.MixedMap{"connections": "99"}, "max_connections": "98"},
+			},
+			want: collection.MixedMap{"server": collection.MixedMap{"max-connections": "98"}},
+		},
+		{
+			name: "environment#normalizeEnvVars_mapped_added",
+			ctx:  collection.MixedMap{"server": collection.MixedMap{"db": collection.MixedMap{"name": "nemo"}}},
+			want: collection.MixedMap{"server": collection.MixedMap{"db": collection.MixedMap{"name": "nemo"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeEnvVars(lower, tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeEnvVars() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,12 +17,19 @@
 package environment
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/photowey/nemo/internel/binder"
 	"github.com/photowey/nemo/internel/eventbus"
@@ -54,17 +61,22 @@ const (
 )
 
 const (
-	PrepareEnvironmentEventName  = "nemo.environment.prepare.event"
-	PreLoadEnvironmentEventName  = "nemo.environment.load.pre.event"
-	PostLoadEnvironmentEventName = "nemo.environment.load.post.event"
-	ConfusedEnvironmentEventName = "nemo.environment.value.confused.event"
+	PrepareEnvironmentEventName   = "nemo.environment.prepare.event"
+	PreLoadEnvironmentEventName   = "nemo.environment.load.pre.event"
+	PostLoadEnvironmentEventName  = "nemo.environment.load.post.event"
+	ConfusedEnvironmentEventName  = "nemo.environment.value.confused.event"
+	RefreshedEnvironmentEventName = "nemo.environment.refreshed.event"
+	ReloadFailedEventName         = "nemo.environment.reload.failed.event"
 )
 
 const (
-	DefaultSystemPropertySourceName = "os.env"
-	DefaultOptionPropertySourceName = "opt.properties"
-	EvnSeparator                    = "="
-	EvnValidLength                  = 2
+	DefaultSystemPropertySourceName  = "os.env"
+	DefaultOptionPropertySourceName  = "opt.properties"
+	DefaultRuntimePropertySourceName = "runtime.properties" // the values of Set | LoadMap, reported by the Origin
+	EvnSeparator                     = "="
+	EvnValidLength                   = 2
+	ProfileSeparator                 = "-"
+	OptionalPrefix                   = "optional:" // optional:/opt/configs/application-local.yml
 )
 
 const (
@@ -72,6 +84,10 @@ const (
 	Yml                       = "yml"
 	Toml                      = "toml"
 	Properties                = "properties"
+	Json                      = "json"
+	Ini                       = "ini"
+	Dotenv                    = "env"
+	Hcl                       = "hcl"
 	DefaultPropertySourceType = Yml
 )
 
@@ -87,6 +103,7 @@ const (
 	AbsoluteFilePriority = ordered.HighPriority + 10*ordered.DefaultStep
 	AbsolutePathPriority = ordered.HighPriority + 20*ordered.DefaultStep
 	SearchPathPriority   = ordered.HighPriority + 30*ordered.DefaultStep
+	ProfilePriorityStep  = ordered.DefaultStep / 10
 )
 
 var (
@@ -106,8 +123,7 @@ var (
 )
 
 var (
-	supportedConfigTypes = stringz.InitStringSlice(Yaml, Yml, Toml, Properties)
-	defaultConfigNames   = stringz.InitStringSlice(
+	defaultConfigNames = stringz.InitStringSlice(
 		IniPropertySourceTyName,
 		ConfPropertySourceTyName,
 		ConfigPropertySourceTyName,
@@ -160,12 +176,19 @@ type Option func(opts *Options)
 type Options struct {
 	AbsolutePaths collection.StringSlice // /opt/data | /opt/configs | ...
 	ConfigNames   collection.StringSlice // application | config | configs | ...
-	ConfigTypes   collection.StringSlice // yaml/yml | toml -> but, json not support now.
+	ConfigTypes   collection.StringSlice // yaml/yml | toml | properties | json | ini | env | hcl
 	SearchPaths   collection.StringSlice // ./resources | ./configs ...
 	Profiles      collection.StringSlice // dev | test | prod | ...
 	Sources       PropertySources        // PropertySource
 	Properties    collection.MixedMap    // Properties -> map data-structure -> can also be replaced by PropertySource
 	Threshold     SuccessThreshold       // the allow count of config files successfully loaded
+	EnvPrefix     string                 // APP -> APP_DB_HOST -> db.host
+	EnvKeyMapper  EnvKeyMapper           // maps the env var name into the nested key, DefaultEnvKeyMapper by default
+	DisableEnv    bool                   // don't load the OS env vars, e.g.: in tests
+	ExportDotenv  bool                   // push the vars of the .env files into the OS env before loading it
+	Watch         bool                   // watch the config files, reload the environment on changes
+	WatchDebounce time.Duration          // the quiet period before reloading, DefaultWatchDebounce by default
+	WatchPolling  time.Duration          // polls the config files by the interval instead of inotify, if > 0
 }
 
 func (opts *Options) validate() (err error) {
@@ -200,6 +223,7 @@ func (opts *Options) validate() (err error) {
 
 func validateAbsolutePaths(absolutePaths collection.StringSlice) error {
 	for _, absolutePath := range absolutePaths {
+		absolutePath, _ = trimOptionalPrefix(absolutePath)
 		if stringz.IsNotBlankString(absolutePath) {
 			if ok := path.IsAbs(absolutePath); !ok {
 				return fmt.Errorf("nemo: the candidate path:[%s] is not absolute path", absolutePath)
@@ -216,7 +240,7 @@ func validateConfigNames(configNames collection.StringSlice) error {
 
 func validateConfigTypes(configTypes collection.StringSlice) error {
 	for _, configType := range configTypes {
-		if collection.ArrayNotContains(supportedConfigTypes, configType) {
+		if collection.ArrayNotContains(supportedConfigTypes(), configType) {
 			return fmt.Errorf("nemo: config type:[%s] not supported", configType)
 		}
 	}
@@ -242,6 +266,11 @@ func validateSources(sources PropertySources) error {
 				return fmt.Errorf("nemo: the `Map` of map property source can't be empty")
 			}
 		}
+		if source.IsBytesSource() {
+			if stringz.IsBlankString(source.Suffix) {
+				return fmt.Errorf("nemo: the `Suffix` of bytes property source can't be blank")
+			}
+		}
 	}
 
 	return nil
@@ -272,7 +301,10 @@ type PropertySource struct {
 	Name            string              // the name of config file. -> e.g.: config.yaml | config.yml config.toml
 	Suffix          string              // the suffix of config file -> Name == config Suffix == yml -> Full name == config.yml
 	Type            reflect.Type        // the type of PropertySource, only support map now. // or string ?
-	Map             collection.MixedMap // the map context, when the Type is map.
+	Map             collection.MixedMap // the map context, when the Type is map | nil.
+	Optional        bool                // the missing config file of the optional PropertySource is loaded as an empty source.
+	FS              fs.FS               // the file system of config file, e.g.: embed.FS, the FilePath is relative to it.
+	Content         []byte              // the in-memory config content, the format is given by the Suffix.
 }
 
 // Order | the priority value of PropertySource sort.
@@ -282,18 +314,72 @@ func (ps PropertySource) Order() int64 {
 	return ps.Priority
 }
 
+// location | the file path of the file source, or the name of the map | bytes source.
+func (ps PropertySource) location() string {
+	if ps.IsBytesSource() || (ps.FS == nil && stringz.IsBlankString(ps.FilePath)) {
+		return ps.Property
+	}
+	if ps.FS != nil {
+		return path.Join(ps.FilePath, ps.fileName())
+	}
+
+	return filepath.Join(ps.FilePath, ps.fileName())
+}
+
+// fileName | the name of the config file, with the Suffix, or the DefaultPropertySourceType, if necessary.
+func (ps PropertySource) fileName() string {
+	if stringz.IsNotBlankString(filepath.Ext(ps.Name)) {
+		return ps.Name
+	}
+
+	return stringz.Concat(ps.Name, stringz.Dot, ps.configType())
+}
+
+// configType | the type of the config file, the ext of the Name first, then the Suffix.
+func (ps PropertySource) configType() string {
+	if ext := strings.TrimPrefix(filepath.Ext(ps.Name), stringz.Dot); stringz.IsNotBlankString(ext) {
+		return ext
+	}
+	if stringz.IsNotBlankString(ps.Suffix) {
+		return ps.Suffix
+	}
+
+	return DefaultPropertySourceType
+}
+
+// open | opens the content of the bytes source, or the config file in the FS, or the OS file system.
+func (ps PropertySource) open() (io.ReadCloser, error) {
+	if ps.IsBytesSource() {
+		return io.NopCloser(bytes.NewReader(ps.Content)), nil
+	}
+	if ps.FS != nil {
+		return ps.FS.Open(path.Join(ps.FilePath, ps.fileName()))
+	}
+
+	return os.Open(filepath.Clean(ps.location()))
+}
+
+// IsMapSource | the Type is map, or the Type is nil and the Map is set.
 func (ps PropertySource) IsMapSource() bool {
-	if ps.Type != nil && ps.Type.Kind() == reflect.Map {
-		return true
+	if ps.Type != nil {
+		return ps.Type.Kind() == reflect.Map
 	}
 
-	return false
+	return ps.Map != nil
+}
+
+// IsBytesSource | the in-memory config content, e.g.: the go:embed []byte | the http body
+func (ps PropertySource) IsBytesSource() bool {
+	return !ps.IsMapSource() && ps.Content != nil
 }
 
 func (ps PropertySource) IsFileSource() bool {
-	if ps.IsMapSource() {
+	if ps.IsMapSource() || ps.IsBytesSource() {
 		return false
 	}
+	if ps.FS != nil {
+		return true
+	}
 
 	if stringz.IsBlankString(ps.FilePath) &&
 		stringz.IsBlankString(ps.Name) &&
@@ -305,7 +391,7 @@ func (ps PropertySource) IsFileSource() bool {
 }
 
 func (ps PropertySource) IsEmptySource() bool {
-	if ps.IsMapSource() || ps.IsFileSource() {
+	if ps.IsMapSource() || ps.IsBytesSource() || ps.IsFileSource() {
 		return false
 	}
 
@@ -320,8 +406,19 @@ type Environment interface {
 	Refresh(opts ...Option) error
 	LoadMap(sourceMap collection.MixedMap) error
 	LoadPropertySources(sources ...PropertySource) error
+	Sources() MutablePropertySources
 	Get(key string) (any, bool)
 	NestedGet(key string) (any, bool)
+	GetWithOrigin(key string) (any, *KeyOrigin, bool)
+	Origin(key string) (*KeyOrigin, bool)
+	GetString(key string, defaultValue string) (string, error)
+	GetInt(key string, defaultValue int) (int, error)
+	GetInt64(key string, defaultValue int64) (int64, error)
+	GetBool(key string, defaultValue bool) (bool, error)
+	GetFloat64(key string, defaultValue float64) (float64, error)
+	GetDuration(key string, defaultValue time.Duration) (time.Duration, error)
+	GetStringSlice(key string, defaultValue collection.StringSlice) (collection.StringSlice, error)
+	GetStringMap(key string, defaultValue collection.MixedMap) (collection.MixedMap, error)
 	Set(key string, value any)
 	NestedSet(key string, value any)
 	Contains(key string) bool
@@ -337,20 +434,33 @@ type Environment interface {
 
 // ----------------------------------------------------------------
 
+// StandardEnvironment | safe for concurrent use: the readers load the immutable snapshot without locks,
+// the writers copy it, then swap it atomically.
 type StandardEnvironment struct {
-	configMap       collection.MixedMap    // core config container
+	state           atomic.Value           // *snapshot, the core config container
+	writeLock       sync.Mutex             // serializes the writers of the state
+	lifecycleLock   sync.Mutex             // serializes Start | Refresh | Destroy | the reload of the watcher
+	sources         []PropertySource       // the property sources of New, every Start rebuilds the config sources from them
 	propertySources []PropertySource       // config sources
-	profiles        collection.StringSlice // Profiles active e.g.: dev test prod ...
+	profiles        collection.StringSlice // Profiles active e.g.: dev test prod ..., published into the state
 	threshold       SuccessThreshold       // threshold
 	binder          *binder.Binder         // default binder
+	envPrefix       string                 // the prefix of the env vars mapped into the nested keys
+	envKeyMapper    EnvKeyMapper           // the env var name mapper
+	envDisabled     bool                   // the OS env vars are not loaded
+	dotenvExported  bool                   // the vars of the .env files are pushed into the OS env
+	watchEnabled    bool                   // the config files are watched
+	watchDebounce   time.Duration          // the quiet period before reloading
+	watchPolling    time.Duration          // the polling interval, inotify is used if <= 0
+	watcher         *watcher               // the running config watcher, if any
 }
 
 // ----------------------------------------------------------------
 
 func New(sources ...PropertySource) Environment {
 	return &StandardEnvironment{
-		configMap:       make(collection.MixedMap),
-		propertySources: sources,
+		sources:         sources,
+		propertySources: make([]PropertySource, 0),
 		profiles:        make(collection.StringSlice, 0),
 		threshold:       NoneSuccessThreshold, // default threshold
 		binder:          binder.New(),
@@ -359,7 +469,13 @@ func New(sources ...PropertySource) Environment {
 
 // ----------------------------------------------------------------
 
+// Start | the listeners of the events must not Start | Refresh | Destroy the same environment.
 func (e *StandardEnvironment) Start(opts ...Option) error {
+	e.stopWatcher()
+
+	e.lifecycleLock.Lock()
+	defer e.lifecycleLock.Unlock()
+
 	optz, err := initOptions(opts...)
 	if err != nil {
 		return err
@@ -369,39 +485,51 @@ func (e *StandardEnvironment) Start(opts ...Option) error {
 	if err != nil {
 		return err
 	}
+	e.publish()
 
 	// prepare
 	eventPrepare := NewStandardEnvironmentEvent(PrepareEnvironmentEventName, e)
 	if err = eventbus.Post(eventPrepare); err != nil {
-		return nil
+		return newStartError(PreparePhase, "", ErrListener, err)
 	}
 
 	// pre load
 	preLoadEvent := NewStandardEnvironmentEvent(PreLoadEnvironmentEventName, e)
 	if err = eventbus.Post(preLoadEvent); err != nil {
-		return nil
+		return newStartError(PreLoadPhase, "", ErrListener, err)
 	}
 
 	// on load
 	if err = e.onLoad(); err != nil {
-		return nil
+		return err
+	}
+
+	// resolve placeholders
+	if _, err = e.resolveProperties(); err != nil {
+		return newStartError(ResolvePhase, "", nil, err)
 	}
 
 	// post load
 	postLoadEvent := NewStandardEnvironmentEvent(PostLoadEnvironmentEventName, e)
 	if err = eventbus.Post(postLoadEvent); err != nil {
-		return nil
+		return newStartError(PostLoadPhase, "", ErrListener, err)
 	}
 
+	e.watchIfNecessary()
+
 	return nil
 }
 
 func (e *StandardEnvironment) Destroy() error {
+	e.stopWatcher()
+
 	return nil
 }
 
 func (e *StandardEnvironment) Refresh(opts ...Option) error {
-	// Destroy ...
+	if err := e.Destroy(); err != nil {
+		return err
+	}
 
 	return e.Start(opts...)
 }
@@ -412,18 +540,15 @@ func (e *StandardEnvironment) LoadMap(sourceMap collection.MixedMap) error {
 	return nil
 }
 
+// LoadPropertySources merges the properties of the sources above the source stack, like LoadMap.
 func (e *StandardEnvironment) LoadPropertySources(sources ...PropertySource) error {
 	for _, source := range sources {
-		if stringz.IsNotBlankString(source.FilePath) {
-			if err := e.loadConfig(source.FilePath, source.Name, source.Suffix, source.Type); err != nil {
-				return err
-			}
+		entry, err := e.loadSource(source)
+		if err != nil {
+			return err
 		}
-
-		if source.IsMapSource() {
-			if err := e.LoadMap(source.Map); err != nil {
-				return err
-			}
+		if err = e.LoadMap(entry.ctx); err != nil {
+			return err
 		}
 	}
 
@@ -431,11 +556,11 @@ func (e *StandardEnvironment) LoadPropertySources(sources ...PropertySource) err
 }
 
 func (e *StandardEnvironment) Get(key string) (any, bool) {
-	return e.getProperty(key)
+	return e.getResolvedProperty(key)
 }
 
 func (e *StandardEnvironment) NestedGet(key string) (any, bool) {
-	return e.getProperty(key)
+	return e.getResolvedProperty(key)
 }
 
 func (e *StandardEnvironment) Set(key string, value any) {
@@ -447,35 +572,54 @@ func (e *StandardEnvironment) NestedSet(key string, value any) {
 }
 
 func (e *StandardEnvironment) Contains(key string) bool {
-	return mapz.NestedContains(key, e.configMap)
+	return mapz.NestedContains(key, e.load().configMap)
 }
 
 func (e *StandardEnvironment) ActiveProfiles() collection.StringSlice {
-	return e.profiles
+	profiles := e.load().profiles
+
+	return append(make(collection.StringSlice, 0, len(profiles)), profiles...)
 }
 
 func (e *StandardEnvironment) ActiveProfilesString() string {
-	return stringz.Implode(e.profiles, stringz.SymbolComma)
+	return stringz.Implode(e.load().profiles, stringz.SymbolComma)
 }
 
 func (e *StandardEnvironment) ActiveDefaultProfile() bool {
-	return collection.ArrayContains(e.profiles, DefaultActiveProfile.String())
+	return collection.ArrayContains(e.load().profiles, DefaultActiveProfile.String())
 }
 
 func (e *StandardEnvironment) Bind(prefix string, target any) error {
-	e.binder.Bind(prefix, target, e.configMap)
+	ctx, err := e.resolveProperties()
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return e.binder.Bind(prefix, target, ctx)
 }
 
 // ----------------------------------------------------------------
 
 func (e *StandardEnvironment) setProperty(key string, value any) {
-	mapz.NestedSet(e.configMap, key, value)
+	e.update(func(ctx collection.MixedMap) {
+		mapz.NestedSet(ctx, key, value)
+	})
+}
+
+func (e *StandardEnvironment) getResolvedProperty(key string) (any, bool) {
+	return e.load().get(key)
 }
 
-func (e *StandardEnvironment) getProperty(key string) (any, bool) {
-	return mapz.NestedGet(e.configMap, key)
+// resolveProperties resolves all the placeholders into a copy of the config container,
+// the raw values are kept so that the later changes of the referenced keys are still visible.
+func (e *StandardEnvironment) resolveProperties() (collection.MixedMap, error) {
+	state := e.load()
+	resolved, err := state.resolver().Resolve(state.configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved.(collection.MixedMap), nil
 }
 
 func (e *StandardEnvironment) mergeMap(ctx collection.MixedMap) {
@@ -483,13 +627,26 @@ func (e *StandardEnvironment) mergeMap(ctx collection.MixedMap) {
 		return
 	}
 
-	mapz.MergeMixedMaps(e.configMap, ctx)
+	// the nested maps of the custom loaders | LoadMap may be map[any]any, e.g.: yaml.v2
+	canonical := mapz.Canonicalize(ctx).(collection.MixedMap)
+
+	e.update(func(configMap collection.MixedMap) {
+		mapz.MergeMixedMaps(configMap, canonical)
+	})
 }
 
+// translateToPropertySources rebuilds the config sources and the profiles from the sources of New and the current options,
+// the ones of the previous Start are dropped.
 func (e *StandardEnvironment) translateToPropertySources(opts *Options) error {
+	e.propertySources = append(make([]PropertySource, 0, len(e.sources)), e.sources...)
+	e.profiles = make(collection.StringSlice, 0)
+
 	e.translateSources(opts)
 	e.translateProfiles(opts)
 	e.translateProperties(opts)
+	e.translateThreshold(opts)
+	e.translateSystemEnv(opts)
+	e.translateWatch(opts)
 	err := e.translatePaths(opts)
 	if err != nil {
 		return err
@@ -521,18 +678,41 @@ func (e *StandardEnvironment) translateProperties(opts *Options) {
 	e.propertySources = append(e.propertySources, ps)
 }
 
+func (e *StandardEnvironment) translateThreshold(opts *Options) {
+	e.threshold = opts.Threshold
+}
+
+func (e *StandardEnvironment) translateSystemEnv(opts *Options) {
+	e.envPrefix = opts.EnvPrefix
+	e.envKeyMapper = opts.EnvKeyMapper
+	e.envDisabled = opts.DisableEnv
+	e.dotenvExported = opts.ExportDotenv
+}
+
+func (e *StandardEnvironment) translateWatch(opts *Options) {
+	e.watchEnabled = opts.Watch
+	e.watchDebounce = opts.WatchDebounce
+	e.watchPolling = opts.WatchPolling
+}
+
 func (e *StandardEnvironment) translatePaths(opts *Options) error {
 	absolutePaths := opts.AbsolutePaths
 	for _, absolutePath := range absolutePaths {
+		absolutePath, optional := trimOptionalPrefix(absolutePath)
+		if isConfigFileCandidate(absolutePath) {
+			e.translateFileToPropertySource(AbsoluteFilePriority, absolutePath, optional)
+			continue
+		}
 		e.translatePathToPropertySourceIfNecessary(AbsoluteFilePriority, AbsolutePathPriority, absolutePath, opts)
 	}
 
 	searchPaths := opts.SearchPaths
 	if collection.IsNotEmptySlice(searchPaths) {
 		for _, searchPath := range searchPaths {
+			searchPath, _ = trimOptionalPrefix(searchPath)
 			abs, err := filez.ToAbsIfNecessary(searchPath)
 			if err != nil {
-				return nil
+				return newStartError(TranslatePhase, searchPath, ErrSourceLoad, err)
 			}
 			e.translatePathToPropertySourceIfNecessary(ordered.DefaultPriority, SearchPathPriority, abs, opts)
 		}
@@ -549,7 +729,7 @@ func (e *StandardEnvironment) translatePathToPropertySourceIfNecessary(filePrior
 	abs = filepath.Clean(abs)
 
 	if filez.IsFile(abs) {
-		e.translateFileToPropertySource(filePriority, abs)
+		e.translateFileToPropertySource(filePriority, abs, false)
 		return
 	}
 
@@ -565,19 +745,31 @@ func (e *StandardEnvironment) translatePathToPropertySourceIfNecessary(filePrior
 	configTypes := opts.ConfigTypes
 	if collection.IsEmptySlice(configTypes) {
 		// custom ?
-		for _, supportedConfigType := range supportedConfigTypes {
+		for _, supportedConfigType := range supportedConfigTypes() {
 			if collection.ArrayNotContains(configTypes, supportedConfigType) {
 				configTypes = append(configTypes, supportedConfigType)
 			}
 		}
 	}
 
+	// the .env file of the path
+	if collection.ArrayContains(configTypes, Dotenv) {
+		e.propertySources = append(e.propertySources, PropertySource{
+			Priority: priority,
+			Property: filepath.Join(abs, stringz.Dot+Dotenv),
+			FilePath: abs,
+			Name:     stringz.Dot + Dotenv,
+			Suffix:   Dotenv,
+			Optional: true,
+		})
+	}
+
 	for _, configName := range configNames {
 		file := filepath.Join(abs, configName)
 		file = filepath.Clean(file)
 
 		if filez.IsFile(file) {
-			e.translateFileToPropertySource(filePriority, file)
+			e.translateFileToPropertySource(filePriority, file, true)
 
 			continue
 		}
@@ -585,23 +777,52 @@ func (e *StandardEnvironment) translatePathToPropertySourceIfNecessary(filePrior
 		for _, configType := range configTypes {
 			ps := PropertySource{
 				Priority: priority,
-				Property: abs,
+				Property: filepath.Join(abs, stringz.Concat(configName, stringz.Dot, configType)),
 				FilePath: abs,
 				Name:     configName,
 				Suffix:   configType,
+				Optional: true,
 			}
 
 			e.propertySources = append(e.propertySources, ps)
 		}
+
+		e.translateProfilesToPropertySources(priority, abs, configName, configTypes)
 	}
 }
 
-func (e *StandardEnvironment) translateFileToPropertySource(priority int64, abs string) {
+// translateProfilesToPropertySources appends the {configName}-{profile}.{type} candidates of the active profiles,
+// the later the profile is declared, the higher the priority it has.
+func (e *StandardEnvironment) translateProfilesToPropertySources(priority int64, abs, configName string, configTypes collection.StringSlice) {
+	for i, profile := range e.profiles {
+		if stringz.IsBlankString(profile) {
+			continue
+		}
+
+		profilePriority := priority - int64(i+1)*ProfilePriorityStep
+		profileConfigName := stringz.Join(ProfileSeparator, configName, profile)
+
+		for _, configType := range configTypes {
+			ps := PropertySource{
+				Priority: profilePriority,
+				Property: filepath.Join(abs, stringz.Concat(profileConfigName, stringz.Dot, configType)),
+				FilePath: abs,
+				Name:     profileConfigName,
+				Suffix:   configType,
+				Optional: true,
+			}
+
+			e.propertySources = append(e.propertySources, ps)
+		}
+	}
+}
+
+func (e *StandardEnvironment) translateFileToPropertySource(priority int64, abs string, optional bool) {
 	abs = filepath.Clean(abs)
 
 	dir := filepath.Dir(abs)
 	fileName := filepath.Base(abs)
-	ext := filepath.Ext(fileName)
+	ext := strings.TrimPrefix(filepath.Ext(fileName), stringz.Dot)
 
 	ps := PropertySource{
 		Priority: priority,
@@ -609,6 +830,7 @@ func (e *StandardEnvironment) translateFileToPropertySource(priority int64, abs
 		FilePath: dir,
 		Name:     fileName,
 		Suffix:   ext,
+		Optional: optional,
 	}
 
 	e.propertySources = append(e.propertySources, ps)
@@ -617,44 +839,140 @@ func (e *StandardEnvironment) translateFileToPropertySource(priority int64, abs
 // ----------------------------------------------------------------
 
 func (e *StandardEnvironment) onLoad() error {
+	e.exportDotenvIfNecessary()
 	e.loadSystemEnvVars()
 
+	return e.loadSources()
+}
+
+// loadSources rebuilds the source stack from the all property sources by the priority, the threshold is checked,
+// the source of the same name is replaced by the one of the higher priority, or the later declared one of the same priority.
+func (e *StandardEnvironment) loadSources() error {
 	sorter := ordered.NewSorter(e.propertySources...)
 	ordered.Sort(sorter, -1)
 
 	th := e.threshold
 
+	fileCounter := 0
 	okCounter := 0
-	errs := make([]error, 0)
+	entries := make([]sourceEntry, 0, len(sorter))
 
 	for _, actor := range sorter {
 		source := actor.(PropertySource)
-		if err := e.LoadPropertySources(source); err != nil {
-			if AllSuccessThreshold.Int() == th.Int() {
+		if source.IsFileSource() {
+			fileCounter++
+		}
+
+		entry, err := e.loadSource(source)
+		switch {
+		case err == nil:
+			if source.IsFileSource() {
+				okCounter++
+			}
+		case source.Optional && errors.Is(err, fs.ErrNotExist):
+			// the missing optional config file: empty, it's loaded by the watcher after it's created.
+			entry = sourceEntry{ctx: make(collection.MixedMap)}
+		default:
+			err = newStartError(LoadPhase, source.location(), ErrSourceLoad, err)
+			if !source.Optional {
 				return err
 			}
-			errs = append(errs, err)
+			if AllSuccessThreshold.Int() == th.Int() {
+				return newStartError(LoadPhase, source.location(), ErrThreshold, err)
+			}
 
-			continue
+			// None | Anyone: the broken optional config file is empty, it's reported by the event, and reloaded by the watcher.
+			_ = eventbus.Post(eventbus.NewStandardAnyEvent(ConfusedEnvironmentEventName, err))
+			entry = sourceEntry{ctx: make(collection.MixedMap)}
 		}
 
-		okCounter++
+		if stringz.IsBlankString(source.Property) {
+			source.Property = source.location()
+		}
+		if index := indexOfSource(entries, source.Property); index >= 0 {
+			entries = append(entries[:index], entries[index+1:]...)
+		}
+		entry.source = source
+		entries = insertSource(entries, 0, entry)
 	}
 
-	if AnyoneSuccessThreshold.Int() == th.Int() && okCounter == 0 {
-		sb := stringz.NewStringBuffer(len(errs))
-		for _, err := range errs {
-			sb.Append(err.Error())
+	// anyone: at least one of the config files is loaded.
+	if AnyoneSuccessThreshold.Int() == th.Int() && fileCounter > 0 && okCounter == 0 {
+		return newStartError(LoadPhase, "", ErrThreshold, nil)
+	}
+
+	_, _ = e.mutate(func(_ []sourceEntry) ([]sourceEntry, error) {
+		return entries, nil
+	}, false)
+
+	return nil
+}
+
+// supportedConfigTypes returns the config types of the registered loaders, e.g.: yaml | yml | toml | ...
+func supportedConfigTypes() collection.StringSlice {
+	return loader.Extensions()
+}
+
+// trimOptionalPrefix trims the `optional:` prefix of the path, e.g.: optional:/opt/configs/application-local.yml
+func trimOptionalPrefix(path string) (string, bool) {
+	if strings.HasPrefix(path, OptionalPrefix) {
+		return strings.TrimPrefix(path, OptionalPrefix), true
+	}
+
+	return path, false
+}
+
+// isConfigFileCandidate reports whether the path is an existing file or a missing one with the supported config type.
+func isConfigFileCandidate(path string) bool {
+	if filez.IsFile(path) {
+		return true
+	}
+	if filez.IsDir(path) {
+		return false
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(path), stringz.Dot)
+
+	return collection.ArrayContains(supportedConfigTypes(), ext)
+}
+
+// exportDotenvIfNecessary pushes the vars of the .env files into the OS env, the existing OS env vars are kept,
+// the failed .env files are reported by the later loading.
+func (e *StandardEnvironment) exportDotenvIfNecessary() {
+	if !e.dotenvExported {
+		return
+	}
+
+	sorter := ordered.NewSorter(e.propertySources...)
+	ordered.Sort(sorter, -1)
+
+	vars := make(collection.MixedMap)
+	for _, actor := range sorter {
+		source := actor.(PropertySource)
+		if !(source.IsFileSource() || source.IsBytesSource()) || Dotenv != source.configType() {
+			continue
 		}
 
-		return fmt.Errorf("nemo: failed to load the all property sources, messages:[%s]", sb.String())
+		if ctx, _, err := e.decodeSource(source); err == nil {
+			mapz.MergeMixedMaps(vars, ctx)
+		}
 	}
 
-	return nil
+	for _, key := range mapz.SortedKeys(vars) {
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		_ = os.Setenv(key, fmt.Sprintf("%v", vars[key]))
+	}
 }
 
 func (e *StandardEnvironment) loadSystemEnvVars() {
+	if e.envDisabled {
+		return
+	}
+
 	envVars := make(collection.MixedMap)
+	nestedEnvVars := make(collection.MixedMap)
 
 	for _, env := range os.Environ() {
 		pair := strings.SplitN(env, EvnSeparator, EvnValidLength) // k=v -> 2
@@ -662,14 +980,40 @@ func (e *StandardEnvironment) loadSystemEnvVars() {
 		// -> ${env} == [ =::=::\ ] ?
 		if len(pair) == 2 {
 			envVars[pair[0]] = pair[1]
+			e.mapSystemEnvVar(nestedEnvVars, pair[0], pair[1])
 
 			postParse(env)
 		}
 	}
 
+	// the nested keys override the raw ones.
+	mapz.MergeMixedMaps(envVars, nestedEnvVars)
+
 	e.loadSystemEnvMap(envVars)
 }
 
+// mapSystemEnvVar maps the env var into the nested keys when the prefix or the mapper is set,
+// e.g.: WithEnvPrefix("APP") -> APP_DB_HOST -> db.host
+func (e *StandardEnvironment) mapSystemEnvVar(ctx collection.MixedMap, name, value string) {
+	mapper := e.envKeyMapper
+	if mapper == nil {
+		if stringz.IsBlankString(e.envPrefix) {
+			return
+		}
+		mapper = DefaultEnvKeyMapper
+	}
+
+	segments, ok := mapper(e.envPrefix, name)
+	if !ok || len(segments) == 0 {
+		return
+	}
+	if _, isIndex := toListIndex(segments[0]); isIndex {
+		return
+	}
+
+	nestedSetIndexed(ctx, segments, value)
+}
+
 func postParse(env string) {
 	for _, confusedEnv := range confusedEnvironments {
 		if strings.Contains(env, confusedEnv) {
@@ -706,42 +1050,38 @@ func (e *StandardEnvironment) loadSystemEnvMapDelayed(envVars collection.MixedMa
 	e.propertySources = append(e.propertySources, envPs)
 }
 
-func (e *StandardEnvironment) loadConfig(path, name, suffix string, _ reflect.Type) error {
-	ext := filepath.Ext(name)
-	if stringz.IsBlankString(ext) {
-		ext = suffix
-		name = stringz.Concat(name, stringz.Dot, ext)
+// decodeSource decodes the file | fs | bytes source by the loader of its config type,
+// the positions of the keys are decoded from the same content, if the loader is a loader.PositionDecoder.
+func (e *StandardEnvironment) decodeSource(source PropertySource) (collection.MixedMap, map[string]loader.Position, error) {
+	ext := source.configType()
+	handler, ok := loader.Select(ext)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: config type:[%s]", loader.ErrLoaderNotFound, ext)
 	}
 
-	if stringz.IsBlankString(ext) {
-		ext = DefaultPropertySourceType
-		name = stringz.Concat(name, stringz.Dot, ext)
+	reader, err := source.open()
+	if err != nil {
+		return nil, nil, err
 	}
+	defer reader.Close()
 
-	loaders := loader.Loaders()
-	sorter := ordered.NewSorter(loaders...)
-	ordered.Sort(sorter, 1)
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	ctx := make(collection.MixedMap)
-	for _, actor := range sorter {
-		handler := actor.(loader.ConfigLoader)
-		if handler.Supports(ext) {
-			filePath := filepath.Clean(filepath.Join(path, name))
-			if err := handler.Load(filePath, &ctx); err != nil {
-				return err
-			}
-		}
+	if err = handler.Decode(bytes.NewReader(content), ctx); err != nil {
+		return nil, nil, fmt.Errorf("nemo: decode config:[%s] failed, %w", source.location(), err)
 	}
 
-	if collection.IsNotEmptyMap(ctx) {
-		if err := e.LoadMap(ctx); err != nil {
-			return err
-		}
+	// the positions are optional: the origin is reported without them.
+	positions, _, err := loader.DecodePositions(handler, bytes.NewReader(content))
+	if err != nil {
+		positions = nil
 	}
 
-	ctx = nil
-
-	return nil
+	return ctx, positions, nil
 }
 
 // ----------------------------------------------------------------
@@ -788,6 +1128,66 @@ func WithProperties(properties collection.MixedMap) Option {
 	}
 }
 
+// WithThreshold | the missing optional config files are empty, the broken required ones fail the start as ErrSourceLoad.
+//
+// None: default, the broken optional config files are empty, each one posts the ConfusedEnvironmentEventName event with its *StartError
+//
+// Anyone: like None, and at least one config file must be loaded | All: the broken optional config file fails the start as ErrThreshold
+func WithThreshold(threshold SuccessThreshold) Option {
+	return func(opts *Options) {
+		opts.Threshold = threshold
+	}
+}
+
+// WithDotenvExported pushes the vars of the .env files into the OS env before loading it, the existing ones are kept.
+func WithDotenvExported() Option {
+	return func(opts *Options) {
+		opts.ExportDotenv = true
+	}
+}
+
+// WithEnvPrefix maps the env vars with the prefix into the nested keys, e.g.: APP -> APP_DB_HOST -> db.host
+func WithEnvPrefix(prefix string) Option {
+	return func(opts *Options) {
+		opts.EnvPrefix = prefix
+	}
+}
+
+func WithEnvKeyMapper(mapper EnvKeyMapper) Option {
+	return func(opts *Options) {
+		opts.EnvKeyMapper = mapper
+	}
+}
+
+// WithSystemEnvDisabled disables the loading of the OS env vars, e.g.: in tests.
+func WithSystemEnvDisabled() Option {
+	return func(opts *Options) {
+		opts.DisableEnv = true
+	}
+}
+
+// WithWatch watches the config files, reloads the environment on changes,
+// then posts the RefreshedEnvironmentEvent with the changed keys, or the ReloadFailedEvent with the error.
+func WithWatch() Option {
+	return func(opts *Options) {
+		opts.Watch = true
+	}
+}
+
+func WithWatchDebounce(debounce time.Duration) Option {
+	return func(opts *Options) {
+		opts.WatchDebounce = debounce
+	}
+}
+
+// WithWatchPolling watches the config files by polling, e.g.: the network file systems without inotify.
+func WithWatchPolling(interval time.Duration) Option {
+	return func(opts *Options) {
+		opts.Watch = true
+		opts.WatchPolling = interval
+	}
+}
+
 // ----------------------------------------------------------------
 
 func initOptions(opts ...Option) (*Options, error) {
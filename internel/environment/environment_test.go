@@ -17,13 +17,21 @@
 package environment
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/photowey/nemo/internel/binder"
 	"github.com/photowey/nemo/internel/eventbus"
+	"github.com/photowey/nemo/internel/loader"
+	"github.com/photowey/nemo/internel/placeholder"
 	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
 )
 
 func TestNew(t *testing.T) {
@@ -50,12 +58,12 @@ func TestNew(t *testing.T) {
 				},
 			},
 			want: &StandardEnvironment{
-				configMap: make(collection.MixedMap),
-				propertySources: []PropertySource{
+				sources: []PropertySource{
 					{Priority: 1, Property: "dev", FilePath: "testdata", Name: "application-dev", Suffix: "yaml"},
 				},
-				profiles: make(collection.StringSlice, 0),
-				binder:   binder.New(),
+				propertySources: make([]PropertySource, 0),
+				profiles:        make(collection.StringSlice, 0),
+				binder:          binder.New(),
 			},
 		},
 	}
@@ -107,7 +115,6 @@ func TestStandardEnvironment_Start(t *testing.T) {
 	properties["hello"] = "world"
 
 	type fields struct {
-		configMap       collection.MixedMap
 		propertySources []PropertySource
 		profiles        collection.StringSlice
 	}
@@ -123,7 +130,6 @@ func TestStandardEnvironment_Start(t *testing.T) {
 		{
 			name: "environment#Start",
 			fields: fields{
-				configMap: make(collection.MixedMap),
 				propertySources: []PropertySource{
 					{Priority: 1, Property: "dev", FilePath: "../../tests/testdata", Name: "application-dev", Suffix: "yml"},
 				},
@@ -146,7 +152,6 @@ func TestStandardEnvironment_Start(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := &StandardEnvironment{
-				configMap:       tt.fields.configMap,
 				propertySources: tt.fields.propertySources,
 				profiles:        tt.fields.profiles,
 			}
@@ -156,3 +161,747 @@ func TestStandardEnvironment_Start(t *testing.T) {
 		})
 	}
 }
+
+func TestStandardEnvironment_Start_Profiles(t *testing.T) {
+	searchPath := t.TempDir()
+
+	files := map[string]string{
+		"application.toml":      "[server]\nport = 8080\nhost = \"localhost\"\n\n[nemo]\nprofile = \"default\"\n",
+		"application-dev.toml":  "[server]\nport = 9527\n\n[nemo]\nprofile = \"dev\"\n",
+		"application-test.toml": "[nemo]\nprofile = \"test\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(searchPath, name), []byte(content), os.ModePerm); err != nil {
+			t.Fatalf("nemo: WriteFile %s failed:%v", name, err)
+		}
+	}
+
+	type args struct {
+		profiles []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want collection.MixedMap
+	}{
+		{
+			name: "environment#Start_profiles_none",
+			args: args{},
+			want: collection.MixedMap{"server.port": int64(8080), "server.host": "localhost", "nemo.profile": "default"},
+		},
+		{
+			name: "environment#Start_profiles_dev",
+			args: args{profiles: []string{"dev"}},
+			want: collection.MixedMap{"server.port": int64(9527), "server.host": "localhost", "nemo.profile": "dev"},
+		},
+		{
+			name: "environment#Start_profiles_dev_test",
+			args: args{profiles: []string{"dev", "test"}},
+			want: collection.MixedMap{"server.port": int64(9527), "server.host": "localhost", "nemo.profile": "test"},
+		},
+		{
+			name: "environment#Start_profiles_test_dev",
+			args: args{profiles: []string{"test", "dev"}},
+			want: collection.MixedMap{"server.port": int64(9527), "server.host": "localhost", "nemo.profile": "dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New()
+			if err := e.Start(
+				WithSearchPaths(searchPath),
+				WithConfigNames("application"),
+				WithConfigTypes("toml"),
+				WithProfiles(tt.args.profiles...),
+			); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			for key, want := range tt.want {
+				if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("NestedGet(%s) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStandardEnvironment_Start_Json(t *testing.T) {
+	abs, err := filepath.Abs("../../tests/testdata/application.json")
+	if err != nil {
+		t.Fatalf("Abs() error = %v", err)
+	}
+
+	e := New()
+	if err = e.Start(WithAbsolutePaths(abs), WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	want := collection.MixedMap{
+		"server.port":           int64(9527),
+		"nemo.datasource.ratio": 0.75,
+		"nemo.rabbitmq.hosts":   []any{"192.168.1.11", "192.168.1.12"},
+	}
+	for key, value := range want {
+		if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, value) {
+			t.Errorf("NestedGet(%s) = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestStandardEnvironment_Start_FSAndBytes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"configs/application.toml": &fstest.MapFile{Data: []byte("[server]\nport = 9527\n[nemo]\nname = \"fs\"\n")},
+	}
+
+	e := New()
+	err := e.Start(
+		WithSources(
+			PropertySource{Priority: ordered.DefaultPriority + 1, Property: "fs", FS: fsys, FilePath: "configs", Name: "application", Suffix: "toml"},
+			PropertySource{Priority: ordered.DefaultPriority, Property: "bytes", Content: []byte("nemo.name=bytes\n"), Suffix: "properties"},
+		),
+		WithSystemEnvDisabled(),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	want := collection.MixedMap{
+		"server.port": int64(9527),
+		"nemo.name":   "bytes",
+	}
+	for key, value := range want {
+		if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, value) {
+			t.Errorf("NestedGet(%s) = %v, want %v", key, got, value)
+		}
+	}
+
+	err = New().Start(WithSources(PropertySource{Property: "bytes", Content: []byte("a=b")}), WithSystemEnvDisabled())
+	if err == nil {
+		t.Errorf("Start() want the error of the bytes source without the Suffix")
+	}
+
+	err = New().Start(WithSources(PropertySource{Property: "fs", FS: fsys, Name: "missing.yml"}), WithSystemEnvDisabled())
+	if !errors.Is(err, ErrSourceLoad) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Start() error = %v, want ErrSourceLoad and fs.ErrNotExist", err)
+	}
+}
+
+// json5Loader loads the *.json5 file as the plain json file.
+type json5Loader struct {
+	loader.ConfigLoader
+}
+
+func (l json5Loader) Name() string {
+	return "json5"
+}
+
+func (l json5Loader) Extensions() collection.StringSlice {
+	return collection.StringSlice{"json5"}
+}
+
+func (l json5Loader) Supports(strategy string) bool {
+	return "json5" == strategy
+}
+
+func TestStandardEnvironment_Start_CustomConfigType(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "application.json5"), []byte(`{"server": {"port": 9527}}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := New().Start(WithConfigTypes("json5"), WithSystemEnvDisabled()); err == nil {
+		t.Fatalf("Start() error = nil, want the unsupported config type error")
+	}
+
+	if err := loader.Register(json5Loader{ConfigLoader: loader.NewJsonConfigLoader()}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	defer loader.Unregister("json5")
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "environment#Start_custom_config_type", opts: []Option{WithSearchPaths(dir), WithConfigTypes("json5")}},
+		{name: "environment#Start_custom_config_type_default_search", opts: []Option{WithSearchPaths(dir)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New()
+			if err := e.Start(append(tt.opts, WithSystemEnvDisabled())...); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			if got, _ := e.NestedGet("server.port"); got != int64(9527) {
+				t.Errorf("NestedGet() got = %v, want %v", got, 9527)
+			}
+		})
+	}
+}
+
+func TestStandardEnvironment_Start_PropertiesMerged(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"application.toml":       "[server]\nport = 8080\nhost = \"localhost\"\n",
+		"application.properties": "server.port=9527\nserver.hosts[0]=a.com\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	e := New()
+	if err := e.Start(WithAbsolutePaths(filepath.Join(dir, "application.properties")), WithSearchPaths(dir), WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	want := collection.MixedMap{
+		"server.port":  "9527",
+		"server.host":  "localhost",
+		"server.hosts": []any{"a.com"},
+	}
+	for key, value := range want {
+		if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, value) {
+			t.Errorf("NestedGet(%s) = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestStandardEnvironment_Start_YamlMerged(t *testing.T) {
+	abs, err := filepath.Abs("../../tests/testdata/application.yml")
+	if err != nil {
+		t.Fatalf("Abs() error = %v", err)
+	}
+
+	e := New()
+	err = e.Start(
+		WithAbsolutePaths(abs),
+		WithSources(PropertySource{Priority: ordered.HighPriority, Property: "override", Content: []byte("nemo.datasource.host=10.0.0.1\n"), Suffix: "properties"}),
+		WithSystemEnvDisabled(),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	want := collection.MixedMap{
+		"nemo.datasource.host":  "10.0.0.1",
+		"nemo.datasource.port":  3306,
+		"nemo.rabbitmq.enabled": true,
+		"nemo.application.name": "nemoapp",
+	}
+	for key, value := range want {
+		if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, value) {
+			t.Errorf("NestedGet(%s) = %v, want %v", key, got, value)
+		}
+	}
+
+	if err = e.LoadMap(collection.MixedMap{"nemo": map[any]any{"datasource": map[any]any{"port": 3307}}}); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+	if got, _ := e.NestedGet("nemo.datasource.port"); got != 3307 {
+		t.Errorf("NestedGet() got = %v, want %v", got, 3307)
+	}
+	if got, _ := e.NestedGet("nemo.datasource.driver"); got != "mysql" {
+		t.Errorf("NestedGet() got = %v, want %v", got, "mysql")
+	}
+}
+
+func TestStandardEnvironment_Start_Dotenv(t *testing.T) {
+	dir := t.TempDir()
+	content := "NEMOTEST_DOTENV_DATABASE_HOST=192.168.1.10\nNEMOTEST_DOTENV_DATABASE_PORT=3306\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	t.Setenv("NEMOTEST_DOTENV_DATABASE_PORT", "3307")
+	t.Cleanup(func() {
+		_ = os.Unsetenv("NEMOTEST_DOTENV_DATABASE_HOST")
+	})
+
+	t.Run("environment#Start_dotenv", func(t *testing.T) {
+		e := New()
+		if err := e.Start(WithSearchPaths(dir), WithSystemEnvDisabled()); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		if got, _ := e.Get("NEMOTEST_DOTENV_DATABASE_HOST"); got != "192.168.1.10" {
+			t.Errorf("Get() got = %v, want %v", got, "192.168.1.10")
+		}
+		if _, ok := os.LookupEnv("NEMOTEST_DOTENV_DATABASE_HOST"); ok {
+			t.Errorf("LookupEnv() the dotenv var should not be exported")
+		}
+	})
+
+	t.Run("environment#Start_dotenv_exported", func(t *testing.T) {
+		e := New()
+		if err := e.Start(WithSearchPaths(dir), WithEnvPrefix("NEMOTEST_DOTENV"), WithDotenvExported()); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		if got := os.Getenv("NEMOTEST_DOTENV_DATABASE_HOST"); got != "192.168.1.10" {
+			t.Errorf("Getenv() got = %v, want %v", got, "192.168.1.10")
+		}
+		if got, _ := e.NestedGet("database.host"); got != "192.168.1.10" {
+			t.Errorf("NestedGet() got = %v, want %v", got, "192.168.1.10")
+		}
+		// the existing OS env var is kept
+		if got, _ := e.NestedGet("database.port"); got != "3307" {
+			t.Errorf("NestedGet() got = %v, want %v", got, "3307")
+		}
+	})
+}
+
+func TestStandardEnvironment_Placeholders(t *testing.T) {
+	t.Setenv("NEMO_TEST_HOST", "192.168.1.10")
+
+	type database struct {
+		Host string `binder:"host"`
+		URL  string `binder:"url"`
+	}
+
+	e := New()
+	if err := e.Start(WithProperties(collection.MixedMap{
+		"database": collection.MixedMap{
+			"host": "${NEMO_TEST_HOST}",
+			"port": 3306,
+			"url":  "mysql://${database.host}:${database.port:3307}/${database.name:nemo}",
+		},
+	})); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if got, _ := e.NestedGet("database.url"); got != "mysql://192.168.1.10:3306/nemo" {
+		t.Errorf("NestedGet() got = %v, want %v", got, "mysql://192.168.1.10:3306/nemo")
+	}
+
+	e.NestedSet("database.name", "nemo_dev")
+	if got, _ := e.Get("database.url"); got != "mysql://192.168.1.10:3306/nemo_dev" {
+		t.Errorf("Get() got = %v, want %v", got, "mysql://192.168.1.10:3306/nemo_dev")
+	}
+
+	got := database{}
+	if err := e.Bind("database", &got); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	want := database{Host: "192.168.1.10", URL: "mysql://192.168.1.10:3306/nemo_dev"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestStandardEnvironment_Start_CircularPlaceholders(t *testing.T) {
+	e := New()
+	err := e.Start(WithProperties(collection.MixedMap{
+		"a": "${b}",
+		"b": "${a}",
+	}))
+	if !errors.Is(err, placeholder.ErrCircularReference) {
+		t.Errorf("Start() error = %v, want %v", err, placeholder.ErrCircularReference)
+	}
+}
+
+func TestStandardEnvironment_Start_EnvPrefix(t *testing.T) {
+	t.Setenv("NEMOTEST_DATABASE_HOST", "10.0.0.1")
+	t.Setenv("NEMOTEST_DATABASE_SERVERS_0", "a.com")
+	t.Setenv("NEMOTEST_DATABASE_USER__NAME", "nemo")
+
+	properties := collection.MixedMap{
+		"database": collection.MixedMap{
+			"host": "192.168.1.10",
+			"port": 3306,
+		},
+	}
+
+	t.Run("environment#Start_env_prefix", func(t *testing.T) {
+		e := New()
+		if err := e.Start(WithProperties(properties), WithEnvPrefix("NEMOTEST")); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		want := collection.MixedMap{
+			"database.host":      "10.0.0.1",
+			"database.port":      3306,
+			"database.servers":   []any{"a.com"},
+			"database.user_name": "nemo",
+		}
+		for key, value := range want {
+			if got, ok := e.NestedGet(key); !ok || !reflect.DeepEqual(got, value) {
+				t.Errorf("NestedGet(%s) = %v, want %v", key, got, value)
+			}
+		}
+	})
+
+	t.Run("environment#Start_env_disabled", func(t *testing.T) {
+		e := New()
+		if err := e.Start(WithProperties(properties), WithEnvPrefix("NEMOTEST"), WithSystemEnvDisabled()); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+
+		if got, _ := e.NestedGet("database.host"); got != "192.168.1.10" {
+			t.Errorf("NestedGet() got = %v, want %v", got, "192.168.1.10")
+		}
+		if e.Contains("NEMOTEST_DATABASE_HOST") {
+			t.Errorf("Contains() the env var should not be loaded")
+		}
+	})
+}
+
+func TestStandardEnvironment_Refresh_Rebuild(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "application.yml"), []byte("name: nemo\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	e := New()
+	err := e.Start(
+		WithSearchPaths(dir),
+		WithProfiles("dev"),
+		WithProperties(collection.MixedMap{"p": "old"}),
+		WithSystemEnvDisabled(),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	names := make([]collection.StringSlice, 0)
+	for i := 0; i < 3; i++ {
+		if err = e.Refresh(WithSearchPaths(dir), WithProperties(collection.MixedMap{"p": "new"}), WithSystemEnvDisabled()); err != nil {
+			t.Fatalf("Refresh() error = %v", err)
+		}
+		names = append(names, e.Sources().Names())
+	}
+
+	if got, _ := e.Get("p"); got != "new" {
+		t.Errorf("Get() got = %v, want %v", got, "new")
+	}
+	if got := e.ActiveProfiles(); !reflect.DeepEqual(got, collection.StringSlice{DefaultActiveProfile.String()}) {
+		t.Errorf("ActiveProfiles() got = %v, want %v", got, DefaultActiveProfile)
+	}
+	if !reflect.DeepEqual(names[0], names[2]) {
+		t.Errorf("Names() got = %v, want %v", names[2], names[0])
+	}
+	// the profile candidates of dev are dropped
+	if collection.ArrayContains(names[2], filepath.Join(dir, "application-dev.yml")) {
+		t.Errorf("Names() got = %v, the dev profile is kept", names[2])
+	}
+}
+
+func TestStandardEnvironment_Start_SamePriority(t *testing.T) {
+	sources := []PropertySource{
+		{Property: "remote", Map: collection.MixedMap{"p": "first"}, Type: reflect.TypeOf(collection.MixedMap{})},
+		{Property: "remote", Map: collection.MixedMap{"p": "second"}, Type: reflect.TypeOf(collection.MixedMap{})},
+	}
+
+	for i := 0; i < 20; i++ {
+		e := New()
+		if err := e.Start(WithSources(sources...), WithSystemEnvDisabled()); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		if got, _ := e.Get("p"); got != "second" {
+			t.Fatalf("Get() got = %v, want %v", got, "second")
+		}
+	}
+}
+
+// confusedListener collects the errors of the confused events.
+type confusedListener struct {
+	errs chan error
+}
+
+func (l *confusedListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l *confusedListener) Name() string {
+	return "nemo.test.confused.listener"
+}
+
+func (l *confusedListener) Topic() collection.StringSlice {
+	return collection.StringSlice{ConfusedEnvironmentEventName}
+}
+
+func (l *confusedListener) Supports(event string) bool {
+	return ConfusedEnvironmentEventName == event
+}
+
+func (l *confusedListener) OnEvent(event eventbus.Event) error {
+	if err, ok := event.Data().(error); ok {
+		l.errs <- err
+	}
+
+	return nil
+}
+
+func TestStandardEnvironment_Start_BrokenOptionalEvent(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "application.toml")
+	if err := os.WriteFile(file, []byte("name = "), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	listener := &confusedListener{errs: make(chan error, 8)}
+	if err := eventbus.Register(listener); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { _ = eventbus.Unregister(listener) })
+
+	if err := New().Start(WithSearchPaths(dir), WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	select {
+	case err := <-listener.errs:
+		var se *StartError
+		if !errors.As(err, &se) || !errors.Is(err, ErrSourceLoad) || file != se.Source {
+			t.Errorf("OnEvent() error = %v, want the *StartError of %v", err, file)
+		}
+	default:
+		t.Errorf("the broken optional config file is not reported")
+	}
+}
+
+func TestStandardEnvironment_Bind_EnvVars(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "application.yml")
+	content := "server:\n  port: 8080\n  max-connections: 10\n  idle-timeout: 1m\n  db:\n    host: 127.0.0.1\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	type server struct {
+		Port           int           `binder:"port"`
+		MaxConnections int           `binder:"max-connections"`
+		IdleTimeout    time.Duration `binder:"idle-timeout"`
+		Host           string        `binder:"db.host"`
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts []Option
+		set  func(e Environment)
+		want server
+	}{
+		{
+			name: "environment#Bind_env_kebab",
+			env:  map[string]string{"SERVER_MAX_CONNECTIONS": "77", "SERVER_IDLETIMEOUT": "2m"},
+			want: server{Port: 8080, MaxConnections: 77, IdleTimeout: 2 * time.Minute, Host: "127.0.0.1"},
+		},
+		{
+			name: "environment#Bind_env_canonical",
+			env:  map[string]string{"SERVER_MAXCONNECTIONS": "78", "SERVER_DB_HOST": "db.nemo"},
+			want: server{Port: 8080, MaxConnections: 78, IdleTimeout: time.Minute, Host: "db.nemo"},
+		},
+		{
+			name: "environment#Bind_env_map_kept",
+			env:  map[string]string{"SERVER": "nemo", "SERVER_DB": "nemo"},
+			want: server{Port: 8080, MaxConnections: 10, IdleTimeout: time.Minute, Host: "127.0.0.1"},
+		},
+		{
+			name: "environment#Bind_env_prefix_kebab",
+			env:  map[string]string{"NEMOTEST_SERVER_MAX_CONNECTIONS": "99", "NEMOTEST_SERVER_IDLE__TIMEOUT": "3m"},
+			opts: []Option{WithEnvPrefix("NEMOTEST")},
+			want: server{Port: 8080, MaxConnections: 99, IdleTimeout: 3 * time.Minute, Host: "127.0.0.1"},
+		},
+		{
+			name: "environment#Bind_env_prefix_added",
+			env:  map[string]string{"NEMOTEST_SERVER_DB_HOST": "db.nemo", "NEMOTEST_SERVER_NAME": "nemo"},
+			opts: []Option{WithEnvPrefix("NEMOTEST")},
+			want: server{Port: 8080, MaxConnections: 10, IdleTimeout: time.Minute, Host: "db.nemo"},
+		},
+		{
+			name: "environment#Bind_env_set_wins",
+			env:  map[string]string{"SERVER_PORT": "1111"},
+			set:  func(e Environment) { e.Set("server.port", 9527) },
+			want: server{Port: 9527, MaxConnections: 10, IdleTimeout: time.Minute, Host: "127.0.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			e := New()
+			if err := e.Start(append([]Option{WithAbsolutePaths(file)}, tt.opts...)...); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if tt.set != nil {
+				tt.set(e)
+			}
+
+			got := server{}
+			if err := e.Bind("server", &got); err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Bind() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	errListenerFailed = errors.New("nemo: test listener failed")
+)
+
+// failingListener fails on the events of the target environment only.
+type failingListener struct {
+	target Environment
+	topic  string
+}
+
+func (l *failingListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l *failingListener) Name() string {
+	return "nemo.test.failing.listener"
+}
+
+func (l *failingListener) Topic() collection.StringSlice {
+	return collection.StringSlice{l.topic}
+}
+
+func (l *failingListener) Supports(event string) bool {
+	return l.topic == event
+}
+
+func (l *failingListener) OnEvent(event eventbus.Event) error {
+	if event.Data() == l.target {
+		return errListenerFailed
+	}
+
+	return nil
+}
+
+func TestStandardEnvironment_Start_ListenerError(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		phase string
+	}{
+		{name: "environment#Start_prepare", topic: PrepareEnvironmentEventName, phase: PreparePhase},
+		{name: "environment#Start_pre_load", topic: PreLoadEnvironmentEventName, phase: PreLoadPhase},
+		{name: "environment#Start_post_load", topic: PostLoadEnvironmentEventName, phase: PostLoadPhase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := New()
+			listener := &failingListener{target: e, topic: tt.topic}
+			if err := eventbus.Register(listener); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			t.Cleanup(func() { _ = eventbus.Unregister(listener) })
+
+			err := e.Start(WithSystemEnvDisabled())
+			if !errors.Is(err, ErrListener) || !errors.Is(err, errListenerFailed) {
+				t.Fatalf("Start() error = %v, want %v", err, ErrListener)
+			}
+
+			var startErr *StartError
+			if !errors.As(err, &startErr) || startErr.Phase != tt.phase {
+				t.Errorf("Start() error = %v, want phase %v", err, tt.phase)
+			}
+		})
+	}
+}
+
+func TestStandardEnvironment_Start_ThresholdError(t *testing.T) {
+	broken := filepath.Join(t.TempDir(), "broken.toml")
+	if err := os.WriteFile(broken, []byte("server = ["), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	e := New()
+	err := e.Start(WithAbsolutePaths(OptionalPrefix+broken), WithThreshold(AllSuccessThreshold), WithSystemEnvDisabled())
+	if !errors.Is(err, ErrThreshold) || !errors.Is(err, ErrSourceLoad) {
+		t.Fatalf("Start() error = %v, want %v", err, ErrThreshold)
+	}
+
+	var startErr *StartError
+	if !errors.As(err, &startErr) || startErr.Phase != LoadPhase || startErr.Source != broken {
+		t.Errorf("Start() error = %v, want the source %v", err, broken)
+	}
+}
+
+func TestStandardEnvironment_Start_OptionalSources(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "application.toml"), []byte("name = \"nemo\""), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	emptyDir := t.TempDir()
+	brokenDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(brokenDir, "application.toml"), []byte("name = "), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "environment#Start_optional_profile_missing",
+			opts: []Option{WithSearchPaths(dir), WithProfiles("local")},
+		},
+		{
+			name: "environment#Start_optional_file_missing",
+			opts: []Option{WithAbsolutePaths(OptionalPrefix + filepath.Join(dir, "application-local.toml"))},
+		},
+		{
+			name:    "environment#Start_required_file_missing",
+			opts:    []Option{WithAbsolutePaths(filepath.Join(emptyDir, "application.toml"))},
+			wantErr: fs.ErrNotExist,
+		},
+		{
+			name:    "environment#Start_required_source_missing",
+			opts:    []Option{WithSources(PropertySource{Property: "missing", FilePath: emptyDir, Name: "application", Suffix: "toml"})},
+			wantErr: ErrSourceLoad,
+		},
+		{
+			name: "environment#Start_anyone_found",
+			opts: []Option{WithSearchPaths(dir), WithThreshold(AnyoneSuccessThreshold)},
+		},
+		{
+			name:    "environment#Start_anyone_none_found",
+			opts:    []Option{WithSearchPaths(emptyDir), WithThreshold(AnyoneSuccessThreshold)},
+			wantErr: ErrThreshold,
+		},
+		{
+			name: "environment#Start_optional_file_broken",
+			opts: []Option{WithSearchPaths(brokenDir)},
+		},
+		{
+			name:    "environment#Start_required_file_broken",
+			opts:    []Option{WithAbsolutePaths(filepath.Join(brokenDir, "application.toml"))},
+			wantErr: ErrSourceLoad,
+		},
+		{
+			name: "environment#Start_anyone_optional_file_broken",
+			opts: []Option{WithSearchPaths(dir, brokenDir), WithThreshold(AnyoneSuccessThreshold)},
+		},
+		{
+			name:    "environment#Start_anyone_all_broken",
+			opts:    []Option{WithSearchPaths(brokenDir), WithThreshold(AnyoneSuccessThreshold)},
+			wantErr: ErrThreshold,
+		},
+		{
+			name:    "environment#Start_all_optional_file_broken",
+			opts:    []Option{WithSearchPaths(dir, brokenDir), WithThreshold(AllSuccessThreshold)},
+			wantErr: ErrThreshold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New().Start(append(tt.opts, WithSystemEnvDisabled())...)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Start() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
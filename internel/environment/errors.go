@@ -0,0 +1,91 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+const (
+	TranslatePhase = "translate"
+	PreparePhase   = "prepare"
+	PreLoadPhase   = "load.pre"
+	LoadPhase      = "load"
+	ResolvePhase   = "resolve"
+	PostLoadPhase  = "load.post"
+)
+
+var (
+	ErrSourceLoad = errors.New("nemo: failed to load the property source")
+	ErrListener   = errors.New("nemo: the event listener failed")
+	ErrThreshold  = errors.New("nemo: the success threshold is not reached")
+
+	ErrSourceNotFound  = errors.New("nemo: the property source is not found")
+	ErrSourceNameBlank = errors.New("nemo: the name of the property source can't be blank")
+)
+
+// ----------------------------------------------------------------
+
+// StartError | the failure of the phase of Start, e.g.:
+//
+// errors.Is(err, ErrThreshold) | errors.Is(err, ErrSourceLoad) | errors.Is(err, fs.ErrNotExist)
+type StartError struct {
+	Phase  string // the failing phase, e.g.: prepare | load | resolve
+	Source string // the failing property source, if any
+	Kind   error  // ErrSourceLoad | ErrListener | ErrThreshold, if any
+	Err    error  // the cause
+}
+
+func newStartError(phase, source string, kind, err error) *StartError {
+	return &StartError{
+		Phase:  phase,
+		Source: source,
+		Kind:   kind,
+		Err:    err,
+	}
+}
+
+func (e *StartError) Error() string {
+	var sb strings.Builder
+	if e.Kind != nil {
+		sb.WriteString(e.Kind.Error())
+	} else {
+		sb.WriteString("nemo: failed to start the environment")
+	}
+
+	sb.WriteString(fmt.Sprintf(", phase:[%s]", e.Phase))
+	if stringz.IsNotBlankString(e.Source) {
+		sb.WriteString(fmt.Sprintf(", source:[%s]", e.Source))
+	}
+	if e.Err != nil {
+		sb.WriteString(fmt.Sprintf(", cause:[%v]", e.Err))
+	}
+
+	return sb.String()
+}
+
+func (e *StartError) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
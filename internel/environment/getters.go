@@ -0,0 +1,93 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"time"
+
+	"github.com/photowey/nemo/internel/binder"
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+// GetAs converts the resolved value of the key into the T by the rules of the Bind,
+// e.g.: yaml 8080 | properties "8080" | toml int64(8080) -> int.
+//
+// The default value is returned if the key is missing or null, or with the *binder.FieldError if it's not convertible,
+// or with the resolve error of the placeholders, e.g.: placeholder.ErrCircularReference
+func GetAs[T any](env Environment, key string, defaultValue T) (T, error) {
+	value, ok, err := resolveOf(env, key)
+	if err != nil {
+		return defaultValue, err
+	}
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+
+	var target T
+	if err := binder.New().Convert(key, value, &target); err != nil {
+		return defaultValue, err
+	}
+
+	return target, nil
+}
+
+// resolveOf | the resolve error is reported by the StandardEnvironment only, the others fall back to Get.
+func resolveOf(env Environment, key string) (any, bool, error) {
+	if standard, ok := env.(*StandardEnvironment); ok {
+		return standard.load().resolve(key)
+	}
+
+	value, ok := env.Get(key)
+
+	return value, ok, nil
+}
+
+// ----------------------------------------------------------------
+
+func (e *StandardEnvironment) GetString(key string, defaultValue string) (string, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+func (e *StandardEnvironment) GetInt(key string, defaultValue int) (int, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+func (e *StandardEnvironment) GetInt64(key string, defaultValue int64) (int64, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+func (e *StandardEnvironment) GetBool(key string, defaultValue bool) (bool, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+func (e *StandardEnvironment) GetFloat64(key string, defaultValue float64) (float64, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+// GetDuration | e.g.: 5s | 1m30s, the number is nanoseconds.
+func (e *StandardEnvironment) GetDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+// GetStringSlice | the list, or the comma-separated string, e.g.: a, b, c
+func (e *StandardEnvironment) GetStringSlice(key string, defaultValue collection.StringSlice) (collection.StringSlice, error) {
+	return GetAs(e, key, defaultValue)
+}
+
+func (e *StandardEnvironment) GetStringMap(key string, defaultValue collection.MixedMap) (collection.MixedMap, error) {
+	return GetAs(e, key, defaultValue)
+}
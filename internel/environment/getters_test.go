@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/photowey/nemo/internel/binder"
+	"github.com/photowey/nemo/internel/placeholder"
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+func TestStandardEnvironment_Getters(t *testing.T) {
+	e := New()
+	if err := e.Start(WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	sources := PropertySources{
+		{Property: "yaml", Content: []byte("yaml:\n  port: 8080\n  ratio: 0.5\n  enabled: true\n  hosts: [a.com, b.com]\n  timeout: 5s\n  empty:\n  name: ${toml.port}\n"), Suffix: "yaml"},
+		{Property: "properties", Content: []byte("properties.port=8080\nproperties.enabled=on\nproperties.hosts=a.com, b.com\n"), Suffix: "properties"},
+		{Property: "toml", Content: []byte("[toml]\nport = 8080\nratio = 0.5\n[toml.labels]\nenv = \"dev\"\n"), Suffix: "toml"},
+	}
+	for _, source := range sources {
+		if err := e.Sources().AddLast(source); err != nil {
+			t.Fatalf("AddLast() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		get     func() (any, error)
+		want    any
+		wantErr bool
+	}{
+		{name: "environment#GetInt_yaml", get: func() (any, error) { return e.GetInt("yaml.port", 0) }, want: 8080},
+		{name: "environment#GetInt_properties", get: func() (any, error) { return e.GetInt("properties.port", 0) }, want: 8080},
+		{name: "environment#GetInt_toml", get: func() (any, error) { return e.GetInt("toml.port", 0) }, want: 8080},
+		{name: "environment#GetInt64_yaml", get: func() (any, error) { return e.GetInt64("yaml.port", 0) }, want: int64(8080)},
+		{name: "environment#GetString_int", get: func() (any, error) { return e.GetString("toml.port", "") }, want: "8080"},
+		{name: "environment#GetString_placeholder", get: func() (any, error) { return e.GetString("yaml.name", "") }, want: "8080"},
+		{name: "environment#GetBool_yaml", get: func() (any, error) { return e.GetBool("yaml.enabled", false) }, want: true},
+		{name: "environment#GetBool_properties", get: func() (any, error) { return e.GetBool("properties.enabled", false) }, want: true},
+		{name: "environment#GetFloat64_toml", get: func() (any, error) { return e.GetFloat64("toml.ratio", 0) }, want: 0.5},
+		{name: "environment#GetDuration", get: func() (any, error) { return e.GetDuration("yaml.timeout", 0) }, want: 5 * time.Second},
+		{
+			name: "environment#GetStringSlice_list",
+			get:  func() (any, error) { return e.GetStringSlice("yaml.hosts", nil) },
+			want: collection.StringSlice{"a.com", "b.com"},
+		},
+		{
+			name: "environment#GetStringSlice_comma",
+			get:  func() (any, error) { return e.GetStringSlice("properties.hosts", nil) },
+			want: collection.StringSlice{"a.com", "b.com"},
+		},
+		{
+			name: "environment#GetStringMap",
+			get:  func() (any, error) { return e.GetStringMap("toml.labels", nil) },
+			want: collection.MixedMap{"env": "dev"},
+		},
+		{name: "environment#GetInt_missing", get: func() (any, error) { return e.GetInt("yaml.missing", 9527) }, want: 9527},
+		{name: "environment#GetInt_null", get: func() (any, error) { return e.GetInt("yaml.empty", 9527) }, want: 9527},
+		{name: "environment#GetInt_mismatch", get: func() (any, error) { return e.GetInt("yaml.hosts", 9527) }, want: 9527, wantErr: true},
+		{name: "environment#GetBool_mismatch", get: func() (any, error) { return e.GetBool("yaml.port", false) }, want: false, wantErr: true},
+		{name: "environment#GetDuration_mismatch", get: func() (any, error) { return e.GetDuration("yaml.enabled", time.Second) }, want: time.Second, wantErr: true},
+		{name: "environment#GetAs_uint16", get: func() (any, error) { return GetAs(e, "properties.port", uint16(0)) }, want: uint16(8080)},
+		{name: "environment#GetAs_overflow", get: func() (any, error) { return GetAs(e, "toml.port", int8(1)) }, want: int8(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("get() got = %v(%T), want %v(%T)", got, got, tt.want, tt.want)
+			}
+
+			var fe *binder.FieldError
+			if tt.wantErr && !errors.As(err, &fe) {
+				t.Errorf("get() error = %v, want *binder.FieldError", err)
+			}
+		})
+	}
+}
+
+func TestGetAs_ResolveError(t *testing.T) {
+	e := New()
+	if err := e.Start(WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	e.Set("app.host", "${server.host}")
+	e.Set("server.host", "${app.host}")
+
+	got, err := GetAs(e, "server.host", "a.com")
+	if !errors.Is(err, placeholder.ErrCircularReference) {
+		t.Errorf("GetAs() error = %v, want %v", err, placeholder.ErrCircularReference)
+	}
+	if got != "a.com" {
+		t.Errorf("GetAs() got = %v, want %v", got, "a.com")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+// PropertyOrigin | the property source which supplies a value of the key.
+type PropertyOrigin struct {
+	Name     string         // the name of the property source, e.g.: os.env | opt.properties | /opt/configs/application.yml
+	FilePath string         // the path of the config file, blank for the map | bytes source
+	Line     int            // the 1-based line of the key, 0 if the loader can't provide it
+	Column   int            // the 1-based column of the key, 0 if the loader can't provide it
+	Value    any            // the raw value, the placeholders are not resolved
+	Source   PropertySource // the property source, blank for the values of Set | LoadMap
+}
+
+// KeyOrigin | the winning property source of the key, then the overridden candidates by the precedence.
+//
+// The nested maps of the candidates are merged, the winner of the map key is the first source which contains it.
+type KeyOrigin struct {
+	Key string
+	PropertyOrigin
+	Overridden []PropertyOrigin
+}
+
+// ----------------------------------------------------------------
+
+func (e *StandardEnvironment) GetWithOrigin(key string) (any, *KeyOrigin, bool) {
+	// the same snapshot for the value and its origin
+	state := e.load()
+	value, ok := state.get(key)
+	if !ok {
+		return nil, nil, false
+	}
+
+	origin, ok := state.origin(key)
+
+	return value, origin, ok
+}
+
+// Origin | the raw values of the candidates are reported, even if the placeholders can't be resolved.
+func (e *StandardEnvironment) Origin(key string) (*KeyOrigin, bool) {
+	return e.load().origin(key)
+}
+
+// ----------------------------------------------------------------
+
+// origin collects the candidates of the key: the values of Set | LoadMap, then the source stack.
+func (s *snapshot) origin(key string) (*KeyOrigin, bool) {
+	candidates := make([]PropertyOrigin, 0)
+	if value, ok := mapz.NestedGet(s.overlay, key); ok {
+		candidates = append(candidates, PropertyOrigin{Name: DefaultRuntimePropertySourceName, Value: value})
+	}
+
+	for _, entry := range s.sources {
+		value, ok := mapz.NestedGet(entry.properties(), key)
+		if !ok {
+			continue
+		}
+
+		candidate := PropertyOrigin{Name: entry.source.Property, Value: value, Source: entry.source}
+		if entry.source.IsFileSource() {
+			candidate.FilePath = entry.source.location()
+		}
+		// the positions are decoded with the ctx, the same snapshot of the source.
+		if position, ok := entry.positions[key]; ok {
+			candidate.Line = position.Line
+			candidate.Column = position.Column
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return nil, false
+	}
+
+	return &KeyOrigin{Key: key, PropertyOrigin: candidates[0], Overridden: candidates[1:]}, true
+}
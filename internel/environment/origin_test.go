@@ -0,0 +1,163 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+func TestStandardEnvironment_Origin(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "application.yaml")
+	if err := os.WriteFile(file, []byte("server:\n  port: 8080\n  host: ${app.host}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	e := New()
+	err := e.Start(
+		WithAbsolutePaths(file),
+		WithProperties(collection.MixedMap{"server": collection.MixedMap{"port": 1, "name": "nemo"}}),
+		WithSystemEnvDisabled(),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if err = e.Sources().AddFirst(PropertySource{Property: "remote", Content: []byte("{\n  \"app\": {\"host\": \"a.com\"}\n}"), Suffix: "json"}); err != nil {
+		t.Fatalf("AddFirst() error = %v", err)
+	}
+
+	type origin struct {
+		Name     string
+		FilePath string
+		Line     int
+		Column   int
+		Value    any
+	}
+	simplify := func(origins ...PropertyOrigin) []origin {
+		simplified := make([]origin, 0, len(origins))
+		for _, o := range origins {
+			simplified = append(simplified, origin{Name: o.Name, FilePath: o.FilePath, Line: o.Line, Column: o.Column, Value: o.Value})
+		}
+
+		return simplified
+	}
+
+	tests := []struct {
+		name           string
+		set            func()
+		key            string
+		wantValue      any
+		wantOrigin     origin
+		wantOverridden []origin
+		wantOk         bool
+	}{
+		{
+			name:           "environment#Origin_file",
+			key:            "server.port",
+			wantValue:      8080,
+			wantOrigin:     origin{Name: file, FilePath: file, Line: 2, Column: 3, Value: 8080},
+			wantOverridden: []origin{{Name: DefaultOptionPropertySourceName, Value: 1}},
+			wantOk:         true,
+		},
+		{
+			name:           "environment#Origin_placeholder",
+			key:            "server.host",
+			wantValue:      "a.com",
+			wantOrigin:     origin{Name: file, FilePath: file, Line: 3, Column: 3, Value: "${app.host}"},
+			wantOverridden: []origin{},
+			wantOk:         true,
+		},
+		{
+			name:           "environment#Origin_bytes",
+			key:            "app.host",
+			wantValue:      "a.com",
+			wantOrigin:     origin{Name: "remote", Line: 2, Column: 11, Value: "a.com"},
+			wantOverridden: []origin{},
+			wantOk:         true,
+		},
+		{
+			name:           "environment#Origin_map",
+			key:            "server.name",
+			wantValue:      "nemo",
+			wantOrigin:     origin{Name: DefaultOptionPropertySourceName, Value: "nemo"},
+			wantOverridden: []origin{},
+			wantOk:         true,
+		},
+		{
+			name:       "environment#Origin_set",
+			set:        func() { e.Set("server.port", 9527) },
+			key:        "server.port",
+			wantValue:  9527,
+			wantOrigin: origin{Name: DefaultRuntimePropertySourceName, Value: 9527},
+			wantOverridden: []origin{
+				{Name: file, FilePath: file, Line: 2, Column: 3, Value: 8080},
+				{Name: DefaultOptionPropertySourceName, Value: 1},
+			},
+			wantOk: true,
+		},
+		{
+			name: "environment#Origin_missing",
+			key:  "server.missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set != nil {
+				tt.set()
+			}
+
+			value, got, ok := e.GetWithOrigin(tt.key)
+			if ok != tt.wantOk {
+				t.Fatalf("GetWithOrigin() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if value != tt.wantValue {
+				t.Errorf("GetWithOrigin() value = %v, want %v", value, tt.wantValue)
+			}
+			if got.Key != tt.key || !reflect.DeepEqual(simplify(got.PropertyOrigin)[0], tt.wantOrigin) {
+				t.Errorf("GetWithOrigin() origin = %v, want %v", simplify(got.PropertyOrigin)[0], tt.wantOrigin)
+			}
+			if !reflect.DeepEqual(simplify(got.Overridden...), tt.wantOverridden) {
+				t.Errorf("GetWithOrigin() overridden = %v, want %v", simplify(got.Overridden...), tt.wantOverridden)
+			}
+		})
+	}
+
+	// the file is changed without the reload: the position is the one of the loaded content.
+	if err = os.WriteFile(file, []byte("\n\nserver:\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if got, ok := e.Origin("server.port"); !ok || len(got.Overridden) != 2 || got.Overridden[0].Line != 2 || got.Overridden[0].Column != 3 {
+		t.Errorf("Origin() got = %v, %v", got, ok)
+	}
+
+	// the circular placeholder: the origin is reported without the value.
+	e.Set("app.host", "${server.host}")
+	if _, _, ok := e.GetWithOrigin("server.host"); ok {
+		t.Errorf("GetWithOrigin() the unresolved value is returned")
+	}
+	if got, ok := e.Origin("app.host"); !ok || got.Name != DefaultRuntimePropertySourceName || len(got.Overridden) != 1 {
+		t.Errorf("Origin() got = %v, %v", got, ok)
+	}
+}
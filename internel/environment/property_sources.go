@@ -0,0 +1,243 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+var (
+	_ MutablePropertySources = (*mutablePropertySources)(nil)
+)
+
+// MutablePropertySources | the property source stack of the environment keyed by the PropertySource.Property,
+// the first one has the highest precedence, the effective config is recomputed from the stack on every change.
+//
+// Start | Refresh rebuild the stack from the configured property sources by the Priority,
+// the values of Set | LoadMap are kept above the stack.
+type MutablePropertySources interface {
+	Get(name string) (PropertySource, bool)
+	Contains(name string) bool
+	Names() collection.StringSlice // by the precedence
+	AddFirst(source PropertySource) error
+	AddLast(source PropertySource) error
+	AddBefore(relative string, source PropertySource) error // higher precedence than the relative one
+	AddAfter(relative string, source PropertySource) error  // lower precedence than the relative one
+	Replace(name string, source PropertySource) error
+	Remove(name string) (PropertySource, bool)
+}
+
+type mutablePropertySources struct {
+	env *StandardEnvironment
+}
+
+func (ps *mutablePropertySources) Get(name string) (PropertySource, bool) {
+	sources := ps.env.load().sources
+	if index := indexOfSource(sources, name); index >= 0 {
+		return sources[index].source, true
+	}
+
+	return PropertySource{}, false
+}
+
+func (ps *mutablePropertySources) Contains(name string) bool {
+	return indexOfSource(ps.env.load().sources, name) >= 0
+}
+
+func (ps *mutablePropertySources) Names() collection.StringSlice {
+	sources := ps.env.load().sources
+	names := make(collection.StringSlice, 0, len(sources))
+	for _, entry := range sources {
+		names = append(names, entry.source.Property)
+	}
+
+	return names
+}
+
+func (ps *mutablePropertySources) AddFirst(source PropertySource) error {
+	return ps.add(source, func(sources []sourceEntry) (int, error) {
+		return 0, nil
+	})
+}
+
+func (ps *mutablePropertySources) AddLast(source PropertySource) error {
+	return ps.add(source, func(sources []sourceEntry) (int, error) {
+		return len(sources), nil
+	})
+}
+
+func (ps *mutablePropertySources) AddBefore(relative string, source PropertySource) error {
+	return ps.add(source, func(sources []sourceEntry) (int, error) {
+		return indexOfRelativeSource(sources, relative, source)
+	})
+}
+
+func (ps *mutablePropertySources) AddAfter(relative string, source PropertySource) error {
+	return ps.add(source, func(sources []sourceEntry) (int, error) {
+		index, err := indexOfRelativeSource(sources, relative, source)
+
+		return index + 1, err
+	})
+}
+
+func (ps *mutablePropertySources) Replace(name string, source PropertySource) error {
+	if stringz.IsBlankString(source.Property) {
+		source.Property = name
+	}
+
+	entry, err := ps.env.loadEntry(source)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.env.mutate(func(sources []sourceEntry) ([]sourceEntry, error) {
+		index := indexOfSource(sources, name)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: name:[%s]", ErrSourceNotFound, name)
+		}
+		sources[index] = entry
+
+		return sources, nil
+	}, true)
+
+	return err
+}
+
+func (ps *mutablePropertySources) Remove(name string) (PropertySource, bool) {
+	var removed PropertySource
+	_, err := ps.env.mutate(func(sources []sourceEntry) ([]sourceEntry, error) {
+		index := indexOfSource(sources, name)
+		if index < 0 {
+			return nil, ErrSourceNotFound
+		}
+		removed = sources[index].source
+
+		return append(sources[:index], sources[index+1:]...), nil
+	}, false)
+
+	return removed, err == nil
+}
+
+// add removes the source of the same name, then inserts the source at the index.
+func (ps *mutablePropertySources) add(source PropertySource, indexOf func(sources []sourceEntry) (int, error)) error {
+	entry, err := ps.env.loadEntry(source)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.env.mutate(func(sources []sourceEntry) ([]sourceEntry, error) {
+		if index := indexOfSource(sources, entry.source.Property); index >= 0 {
+			sources = append(sources[:index], sources[index+1:]...)
+		}
+
+		index, err := indexOf(sources)
+		if err != nil {
+			return nil, err
+		}
+
+		return insertSource(sources, index, entry), nil
+	}, true)
+
+	return err
+}
+
+func indexOfSource(sources []sourceEntry, name string) int {
+	for i, entry := range sources {
+		if name == entry.source.Property {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOfRelativeSource(sources []sourceEntry, relative string, source PropertySource) (int, error) {
+	if relative == source.Property {
+		return -1, fmt.Errorf("nemo: the property source:[%s] can't be relative to itself", relative)
+	}
+
+	index := indexOfSource(sources, relative)
+	if index < 0 {
+		return -1, fmt.Errorf("%w: name:[%s]", ErrSourceNotFound, relative)
+	}
+
+	return index, nil
+}
+
+func insertSource(sources []sourceEntry, index int, entry sourceEntry) []sourceEntry {
+	sources = append(sources, sourceEntry{})
+	copy(sources[index+1:], sources[index:])
+	sources[index] = entry
+
+	return sources
+}
+
+// ----------------------------------------------------------------
+
+func (e *StandardEnvironment) Sources() MutablePropertySources {
+	return &mutablePropertySources{env: e}
+}
+
+// loadEntry loads the properties of the source, the name of the source is the location of the file if it's blank.
+func (e *StandardEnvironment) loadEntry(source PropertySource) (sourceEntry, error) {
+	if err := validateSources(PropertySources{source}); err != nil {
+		return sourceEntry{}, err
+	}
+
+	if stringz.IsBlankString(source.Property) {
+		source.Property = source.location()
+	}
+	if stringz.IsBlankString(source.Property) {
+		return sourceEntry{}, ErrSourceNameBlank
+	}
+
+	entry, err := e.loadSource(source)
+	if err != nil {
+		// the missing optional config file: empty, it's loaded by the watcher | Replace later.
+		if !source.Optional || !errors.Is(err, fs.ErrNotExist) {
+			return sourceEntry{}, err
+		}
+		entry = sourceEntry{source: source, ctx: make(collection.MixedMap)}
+	}
+
+	return entry, nil
+}
+
+// loadSource loads the properties of the map | file | fs | bytes source, with the positions of the keys if any.
+func (e *StandardEnvironment) loadSource(source PropertySource) (sourceEntry, error) {
+	if source.IsMapSource() {
+		return sourceEntry{source: source, ctx: mapz.Canonicalize(source.Map).(collection.MixedMap)}, nil
+	}
+
+	// the file source without the FilePath is ignored.
+	if !source.IsBytesSource() && source.FS == nil && stringz.IsBlankString(source.FilePath) {
+		return sourceEntry{source: source, ctx: make(collection.MixedMap)}, nil
+	}
+
+	ctx, positions, err := e.decodeSource(source)
+	if err != nil {
+		return sourceEntry{}, err
+	}
+
+	return sourceEntry{source: source, ctx: mapz.Canonicalize(ctx).(collection.MixedMap), positions: positions}, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/photowey/nemo/internel/placeholder"
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+func TestMutablePropertySources(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "application.toml")
+	if err := os.WriteFile(file, []byte("[server]\nport = 8080\nhost = \"a.com\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	e := New()
+	if err := e.Start(WithAbsolutePaths(file), WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	e.Set("app.name", "nemo")
+
+	sources := e.Sources()
+	portMap := func(port int) collection.MixedMap {
+		return collection.MixedMap{"server": collection.MixedMap{"port": port}}
+	}
+
+	steps := []struct {
+		name      string
+		change    func() error
+		wantNames collection.StringSlice
+		wantPort  any
+		wantErr   error
+	}{
+		{
+			name:      "environment#Sources_start",
+			change:    func() error { return nil },
+			wantNames: collection.StringSlice{file, DefaultOptionPropertySourceName},
+			wantPort:  int64(8080),
+		},
+		{
+			name:      "environment#Sources_AddLast",
+			change:    func() error { return sources.AddLast(PropertySource{Property: "defaults", Map: portMap(1)}) },
+			wantNames: collection.StringSlice{file, DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  int64(8080),
+		},
+		{
+			name:      "environment#Sources_AddFirst",
+			change:    func() error { return sources.AddFirst(PropertySource{Property: "remote", Map: portMap(9527)}) },
+			wantNames: collection.StringSlice{"remote", file, DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  9527,
+		},
+		{
+			name: "environment#Sources_AddBefore",
+			change: func() error {
+				return sources.AddBefore(file, PropertySource{Property: "bytes", Content: []byte("server.port=7001"), Suffix: "properties"})
+			},
+			wantNames: collection.StringSlice{"remote", "bytes", file, DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  9527,
+		},
+		{
+			name:      "environment#Sources_AddAfter",
+			change:    func() error { return sources.AddAfter(file, PropertySource{Property: "fallback", Map: portMap(2)}) },
+			wantNames: collection.StringSlice{"remote", "bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  9527,
+		},
+		{
+			name:      "environment#Sources_Replace",
+			change:    func() error { return sources.Replace("remote", PropertySource{Map: portMap(9528)}) },
+			wantNames: collection.StringSlice{"remote", "bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  9528,
+		},
+		{
+			name: "environment#Sources_Remove",
+			change: func() error {
+				if _, ok := sources.Remove("remote"); !ok {
+					return ErrSourceNotFound
+				}
+
+				return nil
+			},
+			wantNames: collection.StringSlice{"bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  "7001",
+		},
+		{
+			name:      "environment#Sources_AddBefore_not_found",
+			change:    func() error { return sources.AddBefore("missing", PropertySource{Property: "x", Map: portMap(3)}) },
+			wantNames: collection.StringSlice{"bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  "7001",
+			wantErr:   ErrSourceNotFound,
+		},
+		{
+			name:      "environment#Sources_Replace_not_found",
+			change:    func() error { return sources.Replace("missing", PropertySource{Map: portMap(3)}) },
+			wantNames: collection.StringSlice{"bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  "7001",
+			wantErr:   ErrSourceNotFound,
+		},
+		{
+			name: "environment#Sources_AddFirst_circular",
+			change: func() error {
+				return sources.AddFirst(PropertySource{Property: "circular", Map: collection.MixedMap{"a": "${b}", "b": "${a}"}})
+			},
+			wantNames: collection.StringSlice{"bytes", file, "fallback", DefaultOptionPropertySourceName, "defaults"},
+			wantPort:  "7001",
+			wantErr:   placeholder.ErrCircularReference,
+		},
+	}
+	for _, tt := range steps {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("change() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := sources.Names(); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("Names() got = %v, want %v", got, tt.wantNames)
+			}
+			if got, _ := e.NestedGet("server.port"); got != tt.wantPort {
+				t.Errorf("NestedGet() got = %v(%T), want %v(%T)", got, got, tt.wantPort, tt.wantPort)
+			}
+			// the values of the file source and Set are kept.
+			if got, _ := e.NestedGet("server.host"); got != "a.com" {
+				t.Errorf("NestedGet() got = %v, want %v", got, "a.com")
+			}
+			if got, _ := e.NestedGet("app.name"); got != "nemo" {
+				t.Errorf("NestedGet() got = %v, want %v", got, "nemo")
+			}
+		})
+	}
+
+	if source, ok := sources.Get(file); !ok || source.FilePath != dir {
+		t.Errorf("Get() got = %v, %v", source, ok)
+	}
+	if _, ok := sources.Remove("missing"); ok {
+		t.Errorf("Remove() the missing source is removed")
+	}
+	if err := sources.AddFirst(PropertySource{Map: portMap(1)}); !errors.Is(err, ErrSourceNameBlank) {
+		t.Errorf("AddFirst() error = %v, wantErr %v", err, ErrSourceNameBlank)
+	}
+
+	// Refresh rebuilds the stack from the options.
+	if err := e.Refresh(WithAbsolutePaths(file), WithSystemEnvDisabled()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got, want := sources.Names(), (collection.StringSlice{file, DefaultOptionPropertySourceName}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() got = %v, want %v", got, want)
+	}
+}
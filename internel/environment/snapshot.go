@@ -0,0 +1,204 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/photowey/nemo/internel/eventbus"
+	"github.com/photowey/nemo/internel/loader"
+	"github.com/photowey/nemo/internel/placeholder"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+var (
+	emptySnapshot = &snapshot{
+		configMap: make(collection.MixedMap),
+		sources:   make([]sourceEntry, 0),
+		overlay:   make(collection.MixedMap),
+		profiles:  make(collection.StringSlice, 0),
+	}
+)
+
+// snapshot | the immutable view of the environment, it's never modified after being stored.
+type snapshot struct {
+	configMap   collection.MixedMap    // the effective view: the sources from the last to the first, then the overlay
+	sources     []sourceEntry          // the property source stack, the first one has the highest precedence
+	overlay     collection.MixedMap    // the values of Set | LoadMap, above the all sources
+	profiles    collection.StringSlice // the active profiles
+	envDisabled bool                   // the OS env vars are not the fallback of the placeholders
+}
+
+// sourceEntry | the property source of the stack, with its loaded properties.
+type sourceEntry struct {
+	source    PropertySource
+	ctx       collection.MixedMap
+	view      collection.MixedMap        // the properties merged into the stack, e.g.: the normalized env vars, nil if it's the ctx
+	positions map[string]loader.Position // the positions of the keys, decoded with the ctx, nil if not supported
+}
+
+// properties | the properties of the source merged into the stack.
+func (entry sourceEntry) properties() collection.MixedMap {
+	if entry.view != nil {
+		return entry.view
+	}
+
+	return entry.ctx
+}
+
+// lookup finds the value referenced by a placeholder, falls back to the OS env vars.
+func (s *snapshot) lookup(key string) (any, bool) {
+	if value, ok := mapz.NestedGet(s.configMap, key); ok {
+		return value, true
+	}
+
+	if s.envDisabled {
+		return nil, false
+	}
+
+	return os.LookupEnv(key)
+}
+
+// get resolves the value of the key by the same snapshot,
+// the resolve error is posted as the ConfusedEnvironmentEventName event, e.g.: the circular placeholders.
+func (s *snapshot) get(key string) (any, bool) {
+	resolved, ok, err := s.resolve(key)
+	if err != nil {
+		_ = eventbus.Post(eventbus.NewStandardAnyEvent(ConfusedEnvironmentEventName, err))
+
+		return nil, false
+	}
+
+	return resolved, ok
+}
+
+// resolve resolves the value of the key by the same snapshot, the error wraps the one of the resolver.
+func (s *snapshot) resolve(key string) (any, bool, error) {
+	value, ok := mapz.NestedGet(s.configMap, key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	resolved, err := s.resolver().Resolve(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("nemo: failed to resolve the key:[%s]: %w", key, err)
+	}
+
+	return resolved, true, nil
+}
+
+func (s *snapshot) resolver() *placeholder.Resolver {
+	return placeholder.New(s.lookup)
+}
+
+// mergeSources merges the sources from the lowest precedence to the highest one, then the overlay,
+// the env vars of the os.env source are normalized against the lower sources, the view is kept in the entry.
+func mergeSources(sources []sourceEntry, overlay collection.MixedMap) collection.MixedMap {
+	configMap := make(collection.MixedMap)
+	for i := len(sources) - 1; i >= 0; i-- {
+		sources[i].view = nil
+		if DefaultSystemPropertySourceName == sources[i].source.Property {
+			sources[i].view = normalizeEnvVars(configMap, sources[i].ctx)
+		}
+		mapz.MergeMixedMaps(configMap, sources[i].properties())
+	}
+	mapz.MergeMixedMaps(configMap, overlay)
+
+	return configMap
+}
+
+// ----------------------------------------------------------------
+
+func (e *StandardEnvironment) load() *snapshot {
+	if state, ok := e.state.Load().(*snapshot); ok {
+		return state
+	}
+
+	return emptySnapshot
+}
+
+// update applies the change to the copies of the overlay and the effective view, then swaps them.
+func (e *StandardEnvironment) update(fn func(ctx collection.MixedMap)) {
+	e.writeLock.Lock()
+	defer e.writeLock.Unlock()
+
+	current := e.load()
+	overlay := mapz.DeepCopy(current.overlay).(collection.MixedMap)
+	configMap := mapz.DeepCopy(current.configMap).(collection.MixedMap)
+	fn(overlay)
+	fn(configMap)
+
+	e.state.Store(&snapshot{
+		configMap:   configMap,
+		sources:     current.sources,
+		overlay:     overlay,
+		profiles:    current.profiles,
+		envDisabled: current.envDisabled,
+	})
+}
+
+// mutate applies the change to the copy of the source stack, recomputes the effective view, then swaps it,
+// returns the changed keys. If validated, the broken placeholders keep the current snapshot.
+func (e *StandardEnvironment) mutate(fn func(sources []sourceEntry) ([]sourceEntry, error), validated bool) (collection.StringSlice, error) {
+	e.writeLock.Lock()
+	defer e.writeLock.Unlock()
+
+	current := e.load()
+	sources, err := fn(append(make([]sourceEntry, 0, len(current.sources)), current.sources...))
+	if err != nil {
+		return nil, err
+	}
+
+	next := &snapshot{
+		configMap:   mergeSources(sources, current.overlay),
+		sources:     sources,
+		overlay:     current.overlay,
+		profiles:    current.profiles,
+		envDisabled: current.envDisabled,
+	}
+	if validated {
+		if _, err = next.resolver().Resolve(next.configMap); err != nil {
+			return nil, err
+		}
+	}
+
+	e.state.Store(next)
+
+	changedKeys := make(collection.StringSlice, 0)
+	diffKeys("", current.configMap, next.configMap, &changedKeys)
+	sort.Strings(changedKeys)
+
+	return changedKeys, nil
+}
+
+// publish publishes the profiles and the env settings of the current Start, the config is kept.
+func (e *StandardEnvironment) publish() {
+	e.writeLock.Lock()
+	defer e.writeLock.Unlock()
+
+	current := e.load()
+	e.state.Store(&snapshot{
+		configMap:   current.configMap,
+		sources:     current.sources,
+		overlay:     current.overlay,
+		profiles:    append(make(collection.StringSlice, 0, len(e.profiles)), e.profiles...),
+		envDisabled: e.envDisabled,
+	})
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+// go test -race ./internel/environment/...
+func TestStandardEnvironment_Concurrent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "application.toml")
+	if err := os.WriteFile(file, []byte("[server]\nport = 8080\nhost = \"${server.name:a.com}\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	opts := []Option{
+		WithAbsolutePaths(file),
+		WithProfiles("dev"),
+		WithSystemEnvDisabled(),
+		WithWatchPolling(5 * time.Millisecond),
+		WithWatchDebounce(5 * time.Millisecond),
+	}
+
+	e := New()
+	if err := e.Start(opts...); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer e.Destroy()
+
+	type server struct {
+		Port int64  `binder:"port"`
+		Host string `binder:"host"`
+	}
+
+	done := make(chan struct{})
+	readers := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				if _, ok := e.NestedGet("server.port"); !ok {
+					t.Errorf("NestedGet() the key:[server.port] is missing")
+
+					return
+				}
+				_, _ = e.Get("server.host")
+				_ = e.Contains("server.name")
+				_ = e.ActiveProfiles()
+				_ = e.ActiveProfilesString()
+
+				target := &server{}
+				if err := e.Bind("server", target); err != nil || target.Port == 0 {
+					t.Errorf("Bind() error = %v, target = %v", err, target)
+
+					return
+				}
+			}
+		}()
+	}
+
+	writers := sync.WaitGroup{}
+	writers.Add(3)
+	go func() {
+		defer writers.Done()
+		for i := 0; i < 100; i++ {
+			e.Set("server.name", fmt.Sprintf("%d.com", i))
+		}
+	}()
+	go func() {
+		defer writers.Done()
+		for i := 0; i < 100; i++ {
+			_ = e.LoadMap(collection.MixedMap{"nemo": collection.MixedMap{"index": i}})
+		}
+	}()
+	go func() {
+		defer writers.Done()
+		for i := 0; i < 10; i++ {
+			content := fmt.Sprintf("[server]\nport = %d\nhost = \"${server.name:a.com}\"\n", 9000+i)
+			if err := replaceFile(file, content); err != nil {
+				t.Errorf("replaceFile() error = %v", err)
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			if err := e.Refresh(opts...); err != nil {
+				t.Errorf("Refresh() error = %v", err)
+			}
+		}
+	}()
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+}
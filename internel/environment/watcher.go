@@ -0,0 +1,436 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/photowey/nemo/internel/eventbus"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+const (
+	DefaultWatchDebounce     = 100 * time.Millisecond
+	DefaultWatchPollInterval = time.Second
+)
+
+var (
+	_ eventbus.Event = (*RefreshedEnvironmentEvent)(nil)
+	_ eventbus.Event = (*ReloadFailedEvent)(nil)
+)
+
+// ----------------------------------------------------------------
+
+// RefreshedEnvironmentEvent | posted after the watched config files are reloaded, Data() is the changed keys,
+// the listeners must not Refresh | Destroy the environment synchronously.
+type RefreshedEnvironmentEvent struct {
+	env         Environment
+	changedKeys collection.StringSlice
+}
+
+func NewRefreshedEnvironmentEvent(env Environment, changedKeys collection.StringSlice) *RefreshedEnvironmentEvent {
+	return &RefreshedEnvironmentEvent{
+		env:         env,
+		changedKeys: changedKeys,
+	}
+}
+
+func (e *RefreshedEnvironmentEvent) Name() string {
+	return RefreshedEnvironmentEventName
+}
+
+func (e *RefreshedEnvironmentEvent) Topic() string {
+	return RefreshedEnvironmentEventName
+}
+
+func (e *RefreshedEnvironmentEvent) Data() any {
+	return e.changedKeys
+}
+
+func (e *RefreshedEnvironmentEvent) Environment() Environment {
+	return e.env
+}
+
+// ChangedKeys | the sorted nested keys which are added, removed or changed, e.g.: server.port
+func (e *RefreshedEnvironmentEvent) ChangedKeys() collection.StringSlice {
+	return e.changedKeys
+}
+
+// ----------------------------------------------------------------
+
+// ReloadFailedEvent | posted after the reload of the watched config files fails, Data() is the error,
+// e.g.: the broken config file | the circular placeholders, the current config is kept.
+type ReloadFailedEvent struct {
+	env Environment
+	err error
+}
+
+func NewReloadFailedEvent(env Environment, err error) *ReloadFailedEvent {
+	return &ReloadFailedEvent{
+		env: env,
+		err: err,
+	}
+}
+
+func (e *ReloadFailedEvent) Name() string {
+	return ReloadFailedEventName
+}
+
+func (e *ReloadFailedEvent) Topic() string {
+	return ReloadFailedEventName
+}
+
+func (e *ReloadFailedEvent) Data() any {
+	return e.err
+}
+
+func (e *ReloadFailedEvent) Environment() Environment {
+	return e.env
+}
+
+func (e *ReloadFailedEvent) Err() error {
+	return e.err
+}
+
+// ----------------------------------------------------------------
+
+type fileStat struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func statFile(path string) fileStat {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStat{}
+	}
+
+	return fileStat{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// watcher watches the config files by inotify, falls back to polling, the reload is debounced.
+type watcher struct {
+	env      *StandardEnvironment
+	files    map[string]struct{}
+	debounce time.Duration
+	interval time.Duration
+	changes  chan struct{}
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newWatcher(env *StandardEnvironment, files collection.StringSlice, debounce, interval time.Duration) *watcher {
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	w := &watcher{
+		env:      env,
+		files:    make(map[string]struct{}, len(files)),
+		debounce: debounce,
+		interval: interval,
+		changes:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for _, file := range files {
+		w.files[file] = struct{}{}
+	}
+
+	return w
+}
+
+func (w *watcher) start() {
+	w.wg.Add(1)
+	go w.debounceLoop()
+
+	if w.interval <= 0 {
+		if fw, err := w.notifier(); err == nil {
+			w.wg.Add(1)
+			go w.notifyLoop(fw)
+
+			return
+		}
+
+		// inotify is not available: e.g.: the limit of the watches is reached
+		w.interval = DefaultWatchPollInterval
+	}
+
+	// the stats are taken before the start returns, the later changes are never missed.
+	stats := make(map[string]fileStat, len(w.files))
+	for file := range w.files {
+		stats[file] = statFile(file)
+	}
+
+	w.wg.Add(1)
+	go w.pollLoop(stats)
+}
+
+func (w *watcher) stop() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+// notifier watches the dirs of the files, the editors may replace the file by renaming.
+func (w *watcher) notifier() (*fsnotify.Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[string]struct{})
+	for file := range w.files {
+		dir := filepath.Dir(file)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		dirs[dir] = struct{}{}
+
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if err = fw.Add(dir); err != nil {
+			_ = fw.Close()
+
+			return nil, err
+		}
+	}
+
+	return fw, nil
+}
+
+func (w *watcher) notifyLoop(fw *fsnotify.Watcher) {
+	defer w.wg.Done()
+	defer fw.Close()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-fw.Events:
+			if !ok {
+				return
+			}
+			if _, watched := w.files[filepath.Clean(event.Name)]; watched && !event.Has(fsnotify.Chmod) {
+				w.signal()
+			}
+		case _, ok := <-fw.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (w *watcher) pollLoop(stats map[string]fileStat) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			for file, last := range stats {
+				if current := statFile(file); current != last {
+					stats[file] = current
+					w.signal()
+				}
+			}
+		}
+	}
+}
+
+// signal | the pending change is kept once only.
+func (w *watcher) signal() {
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) debounceLoop() {
+	defer w.wg.Done()
+
+	var timer *time.Timer
+	var fire <-chan time.Time
+
+	for {
+		select {
+		case <-w.done:
+			if timer != nil {
+				timer.Stop()
+			}
+
+			return
+		case <-w.changes:
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(w.debounce)
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			w.env.reloadAndNotify()
+		}
+	}
+}
+
+// ----------------------------------------------------------------
+
+func (e *StandardEnvironment) watchIfNecessary() {
+	if !e.watchEnabled {
+		return
+	}
+
+	files := e.watchedFiles()
+	if len(files) == 0 {
+		return
+	}
+
+	e.watcher = newWatcher(e, files, e.watchDebounce, e.watchPolling)
+	e.watcher.start()
+}
+
+// stopWatcher | the lifecycle lock is released before waiting, the running reload may acquire it.
+func (e *StandardEnvironment) stopWatcher() {
+	e.lifecycleLock.Lock()
+	w := e.watcher
+	e.watcher = nil
+	e.lifecycleLock.Unlock()
+
+	if w != nil {
+		w.stop()
+	}
+}
+
+// watchedFiles returns the absolute paths of the file sources of the stack in the OS file system,
+// the missing optional ones are the empty entries of the stack, they're watched to be created.
+func (e *StandardEnvironment) watchedFiles() collection.StringSlice {
+	files := make(collection.StringSlice, 0)
+	seen := make(map[string]struct{})
+	for _, entry := range e.load().sources {
+		if !isWatchedSource(entry.source) {
+			continue
+		}
+
+		file, err := filepath.Abs(entry.source.location())
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[file]; ok {
+			continue
+		}
+		seen[file] = struct{}{}
+		files = append(files, file)
+	}
+
+	return files
+}
+
+func isWatchedSource(source PropertySource) bool {
+	return source.IsFileSource() && source.FS == nil && !stringz.IsBlankString(source.FilePath)
+}
+
+// reloadAndNotify reloads the property sources, the failed reload keeps the current config, and posts the ReloadFailedEvent.
+func (e *StandardEnvironment) reloadAndNotify() {
+	changedKeys, err := e.reload()
+	if err != nil {
+		_ = eventbus.Post(NewReloadFailedEvent(e, err))
+
+		return
+	}
+	if len(changedKeys) == 0 {
+		return
+	}
+
+	_ = eventbus.Post(NewRefreshedEnvironmentEvent(e, changedKeys))
+}
+
+// reload re-decodes the file sources of the stack in place, the other sources and the values of Set | LoadMap are kept,
+// the missing optional config files are loaded after they're created, e.g.: application-dev.yml
+func (e *StandardEnvironment) reload() (collection.StringSlice, error) {
+	e.lifecycleLock.Lock()
+	defer e.lifecycleLock.Unlock()
+
+	return e.mutate(func(sources []sourceEntry) ([]sourceEntry, error) {
+		for i, entry := range sources {
+			if !isWatchedSource(entry.source) {
+				continue
+			}
+
+			loaded, err := e.loadSource(entry.source)
+			if err != nil {
+				if !entry.source.Optional || !errors.Is(err, fs.ErrNotExist) {
+					return nil, err
+				}
+				loaded = sourceEntry{source: entry.source, ctx: make(collection.MixedMap)}
+			}
+			sources[i] = loaded
+		}
+
+		return sources, nil
+	}, true)
+}
+
+// diffKeys collects the nested keys of the different leaves of the two maps.
+func diffKeys(prefix string, before, after collection.MixedMap, keys *collection.StringSlice) {
+	for _, key := range mapz.SortedKeys(before) {
+		if _, ok := after[key]; !ok {
+			*keys = append(*keys, nestedKey(prefix, key))
+		}
+	}
+
+	for _, key := range mapz.SortedKeys(after) {
+		nested := nestedKey(prefix, key)
+		value := after[key]
+		old, ok := before[key]
+		if !ok {
+			*keys = append(*keys, nested)
+			continue
+		}
+
+		oldMap, oldIsMap := old.(collection.MixedMap)
+		newMap, newIsMap := value.(collection.MixedMap)
+		if oldIsMap && newIsMap {
+			diffKeys(nested, oldMap, newMap, keys)
+			continue
+		}
+		if !reflect.DeepEqual(old, value) {
+			*keys = append(*keys, nested)
+		}
+	}
+}
+
+func nestedKey(prefix, key string) string {
+	if stringz.IsBlankString(prefix) {
+		return key
+	}
+
+	return stringz.Concat(prefix, stringz.Dot, key)
+}
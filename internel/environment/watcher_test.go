@@ -0,0 +1,220 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/photowey/nemo/internel/eventbus"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+)
+
+// refreshedListener collects the refreshed | reload failed events of the target environment only.
+type refreshedListener struct {
+	target   Environment
+	events   chan *RefreshedEnvironmentEvent
+	failures chan error
+}
+
+func (l *refreshedListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l *refreshedListener) Name() string {
+	return "nemo.test.refreshed.listener"
+}
+
+func (l *refreshedListener) Topic() collection.StringSlice {
+	return collection.StringSlice{RefreshedEnvironmentEventName, ReloadFailedEventName}
+}
+
+func (l *refreshedListener) Supports(event string) bool {
+	return RefreshedEnvironmentEventName == event || ReloadFailedEventName == event
+}
+
+func (l *refreshedListener) OnEvent(event eventbus.Event) error {
+	switch evt := event.(type) {
+	case *RefreshedEnvironmentEvent:
+		if evt.Environment() == l.target {
+			l.events <- evt
+		}
+	case *ReloadFailedEvent:
+		if evt.Environment() == l.target {
+			l.failures <- evt.Err()
+		}
+	}
+
+	return nil
+}
+
+// registerRefreshedListener registers the listener of the target environment, it's unregistered on the cleanup.
+func registerRefreshedListener(t *testing.T, target Environment) *refreshedListener {
+	listener := &refreshedListener{
+		target:   target,
+		events:   make(chan *RefreshedEnvironmentEvent, 8),
+		failures: make(chan error, 8),
+	}
+	if err := eventbus.Register(listener); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { _ = eventbus.Unregister(listener) })
+
+	return listener
+}
+
+// replaceFile replaces the file by renaming, the watcher never reads the half-written file.
+func replaceFile(file, content string) error {
+	if err := os.WriteFile(file+".tmp", []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file+".tmp", file)
+}
+
+func TestStandardEnvironment_Start_Watch(t *testing.T) {
+	tests := []struct {
+		name  string
+		watch Option
+	}{
+		{name: "environment#Start_watch_inotify", watch: WithWatch()},
+		{name: "environment#Start_watch_polling", watch: WithWatchPolling(20 * time.Millisecond)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "application.toml")
+			if err := os.WriteFile(file, []byte("[server]\nport = 8080\nhost = \"a.com\"\n"), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			e := New()
+			listener := registerRefreshedListener(t, e)
+
+			if err := e.Start(WithAbsolutePaths(file), WithSystemEnvDisabled(), tt.watch, WithWatchDebounce(20*time.Millisecond)); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			defer e.Destroy()
+
+			// the broken content is not applied
+			if err := replaceFile(file, "[server\n"); err != nil {
+				t.Fatalf("replaceFile() error = %v", err)
+			}
+			select {
+			case err := <-listener.failures:
+				if err == nil {
+					t.Errorf("ReloadFailedEvent.Err() got = nil")
+				}
+			case event := <-listener.events:
+				t.Fatalf("the broken content is applied: %v", event.ChangedKeys())
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the reload failed event is not posted")
+			}
+			if got, _ := e.NestedGet("server.port"); got != int64(8080) {
+				t.Errorf("NestedGet() got = %v, want %v", got, 8080)
+			}
+
+			if err := replaceFile(file, "[server]\nport = 9527\nhost = \"a.com\"\nname = \"nemo\"\n"); err != nil {
+				t.Fatalf("replaceFile() error = %v", err)
+			}
+
+			select {
+			case event := <-listener.events:
+				want := collection.StringSlice{"server.name", "server.port"}
+				if !reflect.DeepEqual(event.ChangedKeys(), want) {
+					t.Errorf("ChangedKeys() got = %v, want %v", event.ChangedKeys(), want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the refreshed event is not posted")
+			}
+
+			if got, _ := e.NestedGet("server.port"); got != int64(9527) {
+				t.Errorf("NestedGet() got = %v, want %v", got, 9527)
+			}
+		})
+	}
+}
+
+func TestStandardEnvironment_Start_Watch_Created(t *testing.T) {
+	tests := []struct {
+		name  string
+		watch Option
+	}{
+		{name: "environment#Start_watch_created_inotify", watch: WithWatch()},
+		{name: "environment#Start_watch_created_polling", watch: WithWatchPolling(20 * time.Millisecond)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "application.toml"), []byte("[server]\nport = 8080\n"), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			e := New()
+			listener := registerRefreshedListener(t, e)
+
+			err := e.Start(WithSearchPaths(dir), WithProfiles("dev"), WithSystemEnvDisabled(), tt.watch, WithWatchDebounce(20*time.Millisecond))
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			defer e.Destroy()
+
+			// the optional profile file is created after the start
+			if err = replaceFile(filepath.Join(dir, "application-dev.toml"), "[server]\nport = 9527\n"); err != nil {
+				t.Fatalf("replaceFile() error = %v", err)
+			}
+
+			select {
+			case event := <-listener.events:
+				want := collection.StringSlice{"server.port"}
+				if !reflect.DeepEqual(event.ChangedKeys(), want) {
+					t.Errorf("ChangedKeys() got = %v, want %v", event.ChangedKeys(), want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the refreshed event is not posted")
+			}
+
+			if got, _ := e.NestedGet("server.port"); got != int64(9527) {
+				t.Errorf("NestedGet() got = %v, want %v", got, 9527)
+			}
+		})
+	}
+}
+
+func TestDiffKeys(t *testing.T) {
+	before := collection.MixedMap{
+		"a": 1,
+		"b": collection.MixedMap{"c": 1, "d": []any{1}},
+		"e": "removed",
+	}
+	after := collection.MixedMap{
+		"a": 1,
+		"b": collection.MixedMap{"c": 2, "d": []any{1}},
+		"f": collection.MixedMap{"g": "added"},
+	}
+
+	keys := make(collection.StringSlice, 0)
+	diffKeys("", before, after, &keys)
+
+	want := collection.StringSlice{"e", "b.c", "f"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("diffKeys() got = %v, want %v", keys, want)
+	}
+}
@@ -45,6 +45,20 @@ func (bus *asyncEventBus) Register(listener EventListener[Event]) error {
 	return listenerNilError
 }
 
+func (bus *asyncEventBus) Unregister(listener EventListener[Event]) error {
+	if listener == nil {
+		return listenerNilError
+	}
+
+	for _, topic := range listener.Topic() {
+		if group, ok := bus.listenerMap[topic]; ok {
+			bus.listenerMap[topic] = removeListener(group, listener)
+		}
+	}
+
+	return nil
+}
+
 func (bus *asyncEventBus) Post(event Event) error {
 	return bus.onEvent(event)
 }
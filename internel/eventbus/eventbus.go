@@ -18,6 +18,7 @@ package eventbus
 
 import (
 	"errors"
+	"reflect"
 )
 
 var (
@@ -41,6 +42,32 @@ var (
 type EventListenerGroup = []EventListener[Event]
 type EventListenerContainer = map[string]EventListenerGroup
 
+// removeListener returns the group without the listener, the group is never modified in place.
+func removeListener(group EventListenerGroup, listener EventListener[Event]) EventListenerGroup {
+	removed := make(EventListenerGroup, 0, len(group))
+	for _, candidate := range group {
+		if !sameListener(candidate, listener) {
+			removed = append(removed, candidate)
+		}
+	}
+
+	return removed
+}
+
+// sameListener matches the listeners by ==, e.g.: the same pointer,
+// the ones of the uncomparable type are matched by the name, e.g.: the struct with a slice field.
+func sameListener(a, b EventListener[Event]) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) {
+		return false
+	}
+	if ta.Comparable() {
+		return a == b
+	}
+
+	return a.Name() == b.Name()
+}
+
 // ----------------------------------------------------------------
 
 func init() {
@@ -51,6 +78,9 @@ func init() {
 
 type EventBus interface {
 	Register(listener EventListener[Event]) error
+	// Unregister removes the listener of the all topics, the listener is matched by ==, e.g.: the same pointer,
+	// or by the name if its type is uncomparable.
+	Unregister(listener EventListener[Event]) error
 	Post(event Event) error
 }
 
@@ -60,6 +90,10 @@ func Register(listener EventListener[Event]) error {
 	return _eventbus.Register(listener)
 }
 
+func Unregister(listener EventListener[Event]) error {
+	return _eventbus.Unregister(listener)
+}
+
 func Post(event Event) error {
 	return _eventbus.Post(event)
 }
@@ -17,9 +17,39 @@
 package eventbus
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+)
+
+var (
+	errTestListener = errors.New("nemo: test listener failed")
 )
 
+type errListener struct{}
+
+func (l errListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l errListener) Name() string {
+	return "nemo.test.err.listener"
+}
+
+func (l errListener) Topic() collection.StringSlice {
+	return collection.StringSlice{"nemo.test.err.event"}
+}
+
+func (l errListener) Supports(event string) bool {
+	return "nemo.test.err.event" == event
+}
+
+func (l errListener) OnEvent(_ Event) error {
+	return errTestListener
+}
+
 func TestPost(t *testing.T) {
 	type args struct {
 		event Event
@@ -46,6 +76,84 @@ func TestPost(t *testing.T) {
 	}
 }
 
+func TestPost_ListenerError(t *testing.T) {
+	if err := Register(errListener{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { _ = Unregister(errListener{}) })
+
+	if err := Post(NewStandardAnyEvent("nemo.test.err.event", "Hello world")); !errors.Is(err, errTestListener) {
+		t.Errorf("Post() error = %v, want %v", err, errTestListener)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	if err := Register(errListener{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := Unregister(errListener{}); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+
+	if err := Post(NewStandardAnyEvent("nemo.test.err.event", "Hello world")); err != nil {
+		t.Errorf("Post() error = %v", err)
+	}
+	if err := Unregister(nil); !errors.Is(err, listenerNilError) {
+		t.Errorf("Unregister() error = %v, want %v", err, listenerNilError)
+	}
+}
+
+// sliceListener | the uncomparable listener.
+type sliceListener struct {
+	topics collection.StringSlice
+}
+
+func (l sliceListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l sliceListener) Name() string {
+	return "nemo.test.slice.listener"
+}
+
+func (l sliceListener) Topic() collection.StringSlice {
+	return l.topics
+}
+
+func (l sliceListener) Supports(event string) bool {
+	return "nemo.test.err.event" == event
+}
+
+func (l sliceListener) OnEvent(_ Event) error {
+	return errTestListener
+}
+
+func TestUnregister_Uncomparable(t *testing.T) {
+	listener := sliceListener{topics: collection.StringSlice{"nemo.test.err.event"}}
+	if err := Register(listener); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := Register(errListener{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { _ = Unregister(errListener{}) })
+
+	if err := Unregister(sliceListener{topics: collection.StringSlice{"nemo.test.err.event"}}); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+
+	// the comparable one is kept
+	if err := Post(NewStandardAnyEvent("nemo.test.err.event", "Hello world")); !errors.Is(err, errTestListener) {
+		t.Errorf("Post() error = %v, want %v", err, errTestListener)
+	}
+	if err := Unregister(errListener{}); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+	if err := Post(NewStandardAnyEvent("nemo.test.err.event", "Hello world")); err != nil {
+		t.Errorf("Post() error = %v", err)
+	}
+}
+
 func TestPostAsync(t *testing.T) {
 	type args struct {
 		event Event
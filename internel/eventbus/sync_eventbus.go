@@ -17,6 +17,8 @@
 package eventbus
 
 import (
+	"sync"
+
 	"github.com/photowey/nemo/pkg/ordered"
 	"github.com/photowey/nemo/pkg/stringz"
 )
@@ -24,6 +26,7 @@ import (
 // ----------------------------------------------------------------
 
 type eventBus struct {
+	lock        sync.RWMutex // the events may be posted by the background goroutines, e.g.: the config watcher
 	listenerMap map[string][]EventListener[Event]
 }
 
@@ -35,6 +38,9 @@ func (bus *eventBus) Register(listener EventListener[Event]) error {
 			return listenerTopicEmptyError
 		}
 
+		bus.lock.Lock()
+		defer bus.lock.Unlock()
+
 		for _, topic := range listener.Topic() {
 			if _, ok := bus.listenerMap[topic]; !ok {
 				bus.listenerMap[topic] = make(EventListenerGroup, 0)
@@ -48,6 +54,23 @@ func (bus *eventBus) Register(listener EventListener[Event]) error {
 	return listenerNilError
 }
 
+func (bus *eventBus) Unregister(listener EventListener[Event]) error {
+	if listener == nil {
+		return listenerNilError
+	}
+
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+
+	for _, topic := range listener.Topic() {
+		if group, ok := bus.listenerMap[topic]; ok {
+			bus.listenerMap[topic] = removeListener(group, listener)
+		}
+	}
+
+	return nil
+}
+
 func (bus *eventBus) Post(event Event) error {
 	return bus.onEvent(event)
 }
@@ -64,7 +87,10 @@ func (bus *eventBus) onEvent(event Event) error {
 		return eventTopicOrNameEmptyError
 	}
 
-	listeners := bus.listenerMap[topic]
+	bus.lock.RLock()
+	listeners := append(make(EventListenerGroup, 0, len(bus.listenerMap[topic])), bus.listenerMap[topic]...)
+	bus.lock.RUnlock()
+
 	sorter := ordered.NewSorter(listeners...)
 	ordered.Sort(sorter, 1)
 
@@ -72,7 +98,7 @@ func (bus *eventBus) onEvent(event Event) error {
 		h := actor.(EventListener[Event])
 		if h.Supports(topic) {
 			if err := h.OnEvent(event); err != nil {
-				return nil
+				return err
 			}
 		}
 	}
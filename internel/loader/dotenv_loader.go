@@ -0,0 +1,298 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+	"github.com/photowey/nemo/pkg/stringz"
+	"github.com/photowey/nemo/pkg/valuez"
+)
+
+// .env | xxx.env
+//
+// # comment
+// export DB_HOST=192.168.1.10
+// DB_NAME='nemo'
+// DB_DSN="root:root@tcp(${DB_HOST}:3306)/${DB_NAME}"
+
+const (
+	Dotenv = "env"
+)
+
+const (
+	dotenvStep     = 1000000
+	dotenvPriority = ordered.HighPriority + dotenvStep*ordered.DefaultStep
+)
+
+const (
+	dotenvExportPrefix = "export "
+	dotenvComment      = '#'
+)
+
+var (
+	dotenvSupportedConfigTypes = stringz.InitStringSlice(Dotenv)
+)
+
+var (
+	_ ConfigLoader    = (*DotenvConfigLoader)(nil)
+	_ PositionDecoder = (*DotenvConfigLoader)(nil)
+)
+
+func init() {
+	_ = Register(NewDotenvConfigLoader())
+}
+
+type DotenvConfigLoader struct {
+}
+
+func NewDotenvConfigLoader() ConfigLoader {
+	return &DotenvConfigLoader{}
+}
+
+func (dcl *DotenvConfigLoader) Supports(strategy string) bool {
+	return collection.ArrayContains(dotenvSupportedConfigTypes, strategy)
+}
+
+func (dcl *DotenvConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(dotenvSupportedConfigTypes)), dotenvSupportedConfigTypes...)
+}
+
+func (dcl *DotenvConfigLoader) Order() int64 {
+	return dotenvPriority
+}
+
+func (dcl *DotenvConfigLoader) Name() string {
+	return Dotenv
+}
+
+func (dcl *DotenvConfigLoader) Load(path string, targetPtr any) error {
+	if valuez.IsNil(targetPtr) {
+		return fmt.Errorf("nemo: load dotenv config file, targetPtr can't be nil")
+	}
+
+	ctx := make(map[string]any)
+	err := dcl.LoadMap(path, ctx)
+	if err != nil {
+		return err
+	}
+
+	return mapstructure.Decode(ctx, targetPtr)
+}
+
+func (dcl *DotenvConfigLoader) LoadMap(path string, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: load dotenv config file, ctx can't be nil")
+	}
+
+	return decodeFile(path, ctx, dcl.Decode)
+}
+
+func (dcl *DotenvConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode dotenv config, ctx can't be nil")
+	}
+
+	vars, err := ParseDotenv(reader)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range vars {
+		ctx[k] = v
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------
+
+// DecodePositions | the positions of the KEY=value lines.
+func (dcl *DotenvConfigLoader) DecodePositions(reader io.Reader) (map[string]Position, error) {
+	positions := make(map[string]Position)
+	if _, err := parseDotenv(reader, positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// ----------------------------------------------------------------
+
+// ParseDotenv parses the KEY=value lines, ${VAR} is interpolated by the former vars, then the process env,
+// the unknown one is kept as it is.
+func ParseDotenv(reader io.Reader) (map[string]string, error) {
+	return parseDotenv(reader, nil)
+}
+
+// parseDotenv | the positions of the keys are recorded, if the positions isn't nil.
+func parseDotenv(reader io.Reader, positions map[string]Position) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	scanner := bufio.NewScanner(reader)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if stringz.IsBlankString(line) || dotenvComment == line[0] {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, dotenvExportPrefix))
+
+		index := strings.Index(line, "=")
+		if index <= 0 {
+			return nil, fmt.Errorf("line:[%d] invalid key-value pair:[%s]", lineNo, line)
+		}
+
+		key := strings.TrimSpace(line[:index])
+		if strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line:[%d] invalid key:[%s]", lineNo, key)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(line[index+1:]), vars)
+		if err != nil {
+			return nil, fmt.Errorf("line:[%d] %w", lineNo, err)
+		}
+
+		vars[key] = value
+		if positions != nil {
+			raw := scanner.Text()
+			rest := strings.TrimLeft(strings.TrimPrefix(strings.TrimLeft(raw, " \t"), dotenvExportPrefix), " \t")
+			positions[key] = Position{Line: lineNo, Column: len(raw) - len(rest) + 1}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+func parseDotenvValue(value string, vars map[string]string) (string, error) {
+	if stringz.IsBlankString(value) {
+		return value, nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value:[%s]", value)
+		}
+
+		return value[1 : end+1], nil
+	case '"':
+		return parseDoubleQuotedDotenvValue(value, vars)
+	default:
+		for i := 1; i < len(value); i++ {
+			if dotenvComment == value[i] && (' ' == value[i-1] || '\t' == value[i-1]) {
+				value = strings.TrimSpace(value[:i])
+				break
+			}
+		}
+
+		return expandDotenvValue(value, vars), nil
+	}
+}
+
+func parseDoubleQuotedDotenvValue(value string, vars map[string]string) (string, error) {
+	var buf strings.Builder
+	for i := 1; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case '"' == ch:
+			return buf.String(), nil
+		case '\\' == ch && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				buf.WriteByte('\n')
+			case 't':
+				buf.WriteByte('\t')
+			case 'r':
+				buf.WriteByte('\r')
+			case '$':
+				// the escaped `$` is never expanded
+				buf.WriteByte('$')
+			default:
+				buf.WriteByte(value[i])
+			}
+		case '$' == ch && i+1 < len(value) && '{' == value[i+1]:
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				buf.WriteByte(ch)
+				continue
+			}
+			buf.WriteString(expandDotenvValue(value[i:i+end+1], vars))
+			i += end
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated quoted value:[%s]", value)
+}
+
+// expandDotenvValue expands the ${VAR} references, `\$` is kept as a literal `$`.
+func expandDotenvValue(value string, vars map[string]string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if '\\' == ch && i+1 < len(value) && '$' == value[i+1] {
+			buf.WriteByte('$')
+			i++
+			continue
+		}
+
+		if '$' != ch || i+1 >= len(value) || '{' != value[i+1] {
+			buf.WriteByte(ch)
+			continue
+		}
+
+		end := strings.IndexByte(value[i:], '}')
+		if end < 0 {
+			buf.WriteString(value[i:])
+			break
+		}
+
+		name := value[i+2 : i+end]
+		if v, ok := vars[name]; ok {
+			buf.WriteString(v)
+		} else if v, ok = os.LookupEnv(name); ok {
+			buf.WriteString(v)
+		} else {
+			buf.WriteString(value[i : i+end+1])
+		}
+		i += end
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDotenvConfigLoader_Load(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	testdataDir := filepath.Dir(testFile)
+
+	absPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/application.env"))
+	badAbsPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/config.env")) // not found
+
+	ctx := make(map[string]any)
+
+	type args struct {
+		path      string
+		targetPtr any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "loader#dotenv_ok",
+			args: args{
+				path:      absPath,
+				targetPtr: &ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader#dotenv_failed",
+			args: args{
+				path:      badAbsPath,
+				targetPtr: &ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcl := NewDotenvConfigLoader()
+			if err := dcl.Load(tt.args.path, tt.args.targetPtr); (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDotenvConfigLoader_LoadMap(t *testing.T) {
+	t.Setenv("NEMO_DOTENV_OS_HOST", "10.0.0.1")
+
+	testFile := determineTestSourceFilePath()
+	absPath := filepath.Clean(filepath.Join(filepath.Dir(testFile), "../../tests/testdata/application.env"))
+
+	ctx := make(map[string]any)
+	if err := NewDotenvConfigLoader().LoadMap(absPath, ctx); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{name: "loader#dotenv_plain", key: "NEMO_APP_NAME", want: "nemoapp"},
+		{name: "loader#dotenv_export", key: "NEMO_DB_HOST", want: "192.168.1.10"},
+		{name: "loader#dotenv_inline_comment", key: "NEMO_DB_PORT", want: "3306"},
+		{name: "loader#dotenv_single_quoted", key: "NEMO_DB_PASSWORD", want: "root#${NEMO_DB_HOST}"},
+		{name: "loader#dotenv_double_quoted", key: "NEMO_DB_DSN", want: "root:root@tcp(192.168.1.10:3306)/nemo\tcharset=\"utf8mb4\""},
+		{name: "loader#dotenv_escaped", key: "NEMO_DB_ESCAPED", want: "${NEMO_DB_HOST}"},
+		{name: "loader#dotenv_os_env", key: "NEMO_DB_OS", want: "10.0.0.1"},
+		{name: "loader#dotenv_unknown", key: "NEMO_DB_UNKNOWN", want: "${NEMO_DB_NOT_FOUND}"},
+		{name: "loader#dotenv_empty", key: "NEMO_DB_EMPTY", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ctx[tt.key]; got != tt.want {
+				t.Errorf("LoadMap() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDotenv_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "dotenv#invalid_pair", text: "NEMO_APP_NAME"},
+		{name: "dotenv#invalid_key", text: "NEMO APP=nemo"},
+		{name: "dotenv#unterminated_single_quote", text: "NEMO_APP_NAME='nemo"},
+		{name: "dotenv#unterminated_double_quote", text: "NEMO_APP_NAME=\"nemo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDotenv(strings.NewReader(tt.text)); err == nil {
+				t.Errorf("ParseDotenv() error = nil, want error")
+			}
+		})
+	}
+}
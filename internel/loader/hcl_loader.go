@@ -0,0 +1,228 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/mitchellh/mapstructure"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+	"github.com/photowey/nemo/pkg/stringz"
+	"github.com/photowey/nemo/pkg/valuez"
+)
+
+// xxx.hcl
+//
+// server {               -> server.port
+//   port = 8080
+// }
+// database "primary" {   -> database.primary.host
+//   host = "192.168.1.10"
+// }
+// listener { port = 80 }
+// listener { port = 443 } -> listener: [{port: 80} {port: 443}]
+
+const (
+	Hcl = "hcl"
+)
+
+const (
+	hclStep     = 10000000
+	hclPriority = ordered.HighPriority + hclStep*ordered.DefaultStep
+)
+
+var (
+	hclSupportedConfigTypes = stringz.InitStringSlice(Hcl)
+)
+
+var (
+	_ ConfigLoader = (*HclConfigLoader)(nil)
+)
+
+func init() {
+	_ = Register(NewHclConfigLoader())
+}
+
+type HclConfigLoader struct {
+}
+
+func NewHclConfigLoader() ConfigLoader {
+	return &HclConfigLoader{}
+}
+
+func (hl *HclConfigLoader) Supports(strategy string) bool {
+	return collection.ArrayContains(hclSupportedConfigTypes, strategy)
+}
+
+func (hl *HclConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(hclSupportedConfigTypes)), hclSupportedConfigTypes...)
+}
+
+func (hl *HclConfigLoader) Order() int64 {
+	return hclPriority
+}
+
+func (hl *HclConfigLoader) Name() string {
+	return Hcl
+}
+
+func (hl *HclConfigLoader) Load(path string, targetPtr any) error {
+	if valuez.IsNil(targetPtr) {
+		return fmt.Errorf("nemo: load hcl config file, targetPtr can't be nil")
+	}
+
+	ctx := make(map[string]any)
+	err := hl.LoadMap(path, ctx)
+	if err != nil {
+		return err
+	}
+
+	return mapstructure.Decode(ctx, targetPtr)
+}
+
+func (hl *HclConfigLoader) LoadMap(path string, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: load hcl config file, ctx can't be nil")
+	}
+
+	return decodeFile(path, ctx, hl.Decode)
+}
+
+func (hl *HclConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode hcl config, ctx can't be nil")
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	return decodeHcl(data, ctx)
+}
+
+// ----------------------------------------------------------------
+
+func decodeHcl(data []byte, ctx map[string]any) (err error) {
+	file, err := hcl.ParseBytes(data)
+	if err != nil {
+		return err
+	}
+
+	list, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return fmt.Errorf("the root of the hcl file must be an object")
+	}
+
+	// token.Value panics on the invalid literal, e.g.: the overflowed number.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid hcl value: %v", r)
+		}
+	}()
+
+	return putHclObjectList(ctx, list)
+}
+
+// putHclObjectList puts the attributes and the blocks into the ctx, the labels of the block are the nested keys,
+// the repeated blocks are collected into a list.
+func putHclObjectList(ctx map[string]any, list *ast.ObjectList) error {
+	for _, item := range list.Items {
+		keys := make(collection.StringSlice, 0, len(item.Keys))
+		for _, key := range item.Keys {
+			keys = append(keys, fmt.Sprintf("%v", key.Token.Value()))
+		}
+
+		value, err := hclValue(item.Val)
+		if err != nil {
+			return err
+		}
+
+		current := ctx
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := current[key].(map[string]any)
+			if !ok {
+				if _, exists := current[key]; exists {
+					return fmt.Errorf("line:[%d] key:[%s] is both a value and a block", item.Pos().Line, key)
+				}
+				child = make(map[string]any)
+				current[key] = child
+			}
+			current = child
+		}
+
+		last := keys[len(keys)-1]
+		block := !item.Assign.IsValid()
+		switch existing := current[last].(type) {
+		case map[string]any:
+			if block {
+				current[last] = []any{existing, value}
+				continue
+			}
+		case []any:
+			if block && isHclBlockList(existing) {
+				current[last] = append(existing, value)
+				continue
+			}
+		}
+
+		current[last] = value
+	}
+
+	return nil
+}
+
+func hclValue(node ast.Node) (any, error) {
+	switch v := node.(type) {
+	case *ast.LiteralType:
+		return v.Token.Value(), nil
+	case *ast.ListType:
+		list := make([]any, 0, len(v.List))
+		for _, element := range v.List {
+			value, err := hclValue(element)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+
+		return list, nil
+	case *ast.ObjectType:
+		ctx := make(map[string]any)
+		if err := putHclObjectList(ctx, v.List); err != nil {
+			return nil, err
+		}
+
+		return ctx, nil
+	default:
+		return nil, fmt.Errorf("line:[%d] unsupported hcl node:[%T]", node.Pos().Line, node)
+	}
+}
+
+func isHclBlockList(list []any) bool {
+	for _, element := range list {
+		if _, ok := element.(map[string]any); !ok {
+			return false
+		}
+	}
+
+	return len(list) > 0
+}
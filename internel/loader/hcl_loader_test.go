@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+func TestHclConfigLoader_Load(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	testdataDir := filepath.Dir(testFile)
+
+	absPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/application.hcl"))
+	badAbsPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/config.hcl")) // not found
+
+	ctx := make(map[string]any)
+
+	type args struct {
+		path      string
+		targetPtr any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "loader#hcl_ok",
+			args: args{
+				path:      absPath,
+				targetPtr: &ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader#hcl_failed",
+			args: args{
+				path:      badAbsPath,
+				targetPtr: &ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hl := NewHclConfigLoader()
+			if err := hl.Load(tt.args.path, tt.args.targetPtr); (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHclConfigLoader_LoadMap(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	absPath := filepath.Clean(filepath.Join(filepath.Dir(testFile), "../../tests/testdata/application.hcl"))
+
+	ctx := make(map[string]any)
+	if err := NewHclConfigLoader().LoadMap(absPath, ctx); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{name: "loader#hcl_int64", key: "server.port", want: int64(9527)},
+		{name: "loader#hcl_float64", key: "server.ratio", want: 0.75},
+		{name: "loader#hcl_list", key: "server.hosts", want: []any{"a.com", "b.com"}},
+		{name: "loader#hcl_labeled_block", key: "nemo.datasource.primary.host", want: "192.168.1.10"},
+		{name: "loader#hcl_labeled_block_bool", key: "nemo.datasource.primary.enabled", want: true},
+		{name: "loader#hcl_labeled_block_sibling", key: "nemo.datasource.replica.host", want: "192.168.1.11"},
+		{name: "loader#hcl_repeated_block", key: "listener", want: []any{map[string]any{"port": int64(80)}, map[string]any{"port": int64(443)}}},
+		{name: "loader#hcl_heredoc", key: "banner", want: "hello nemo\n"},
+		{name: "loader#hcl_object_attribute", key: "labels.env", want: "dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := mapz.NestedGet(ctx, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NestedGet() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeHcl_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "hcl#syntax", text: "server {"},
+		{name: "hcl#overflow", text: "port = 99999999999999999999"},
+		{name: "hcl#value_and_block", text: "nemo = 1\nnemo \"datasource\" {\n host = \"a\"\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := decodeHcl([]byte(tt.text), make(map[string]any)); err == nil {
+				t.Errorf("decodeHcl() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+	"github.com/photowey/nemo/pkg/stringz"
+	"github.com/photowey/nemo/pkg/valuez"
+)
+
+// xxx.ini
+//
+// ; comment | # comment
+// [server.http] -> server.http.port
+// port = 8080
+// hosts = a.com
+// hosts = b.com -> [a.com b.com]
+// banner = "hello \
+//   world"
+
+const (
+	Ini = "ini"
+)
+
+const (
+	iniStep     = 100000
+	iniPriority = ordered.HighPriority + iniStep*ordered.DefaultStep
+)
+
+const (
+	iniContinuation = `\`
+	iniCommentChars = ";#"
+	iniAssignChars  = "=:"
+)
+
+var (
+	iniSupportedConfigTypes = stringz.InitStringSlice(Ini)
+)
+
+var (
+	_ ConfigLoader    = (*IniConfigLoader)(nil)
+	_ PositionDecoder = (*IniConfigLoader)(nil)
+)
+
+func init() {
+	_ = Register(NewIniConfigLoader())
+}
+
+type IniConfigLoader struct {
+}
+
+func NewIniConfigLoader() ConfigLoader {
+	return &IniConfigLoader{}
+}
+
+func (icl *IniConfigLoader) Supports(strategy string) bool {
+	return collection.ArrayContains(iniSupportedConfigTypes, strategy)
+}
+
+func (icl *IniConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(iniSupportedConfigTypes)), iniSupportedConfigTypes...)
+}
+
+func (icl *IniConfigLoader) Order() int64 {
+	return iniPriority
+}
+
+func (icl *IniConfigLoader) Name() string {
+	return Ini
+}
+
+func (icl *IniConfigLoader) Load(path string, targetPtr any) error {
+	if valuez.IsNil(targetPtr) {
+		return fmt.Errorf("nemo: load ini config file, targetPtr can't be nil")
+	}
+
+	ctx := make(map[string]any)
+	err := icl.LoadMap(path, ctx)
+	if err != nil {
+		return err
+	}
+
+	return mapstructure.Decode(ctx, targetPtr)
+}
+
+func (icl *IniConfigLoader) LoadMap(path string, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: load ini config file, ctx can't be nil")
+	}
+
+	return decodeFile(path, ctx, icl.Decode)
+}
+
+func (icl *IniConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode ini config, ctx can't be nil")
+	}
+
+	return parseIni(reader, ctx, nil)
+}
+
+// DecodePositions | the positions of the sections and the keys.
+func (icl *IniConfigLoader) DecodePositions(reader io.Reader) (map[string]Position, error) {
+	positions := make(map[string]Position)
+	if err := parseIni(reader, make(map[string]any), positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// ----------------------------------------------------------------
+
+// parseIni | the positions of the keys are recorded, if the positions isn't nil.
+func parseIni(reader io.Reader, ctx map[string]any, positions map[string]Position) error {
+	scanner := bufio.NewScanner(reader)
+
+	section := make(collection.StringSlice, 0)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		column := indentOf(scanner.Text()) + 1
+
+		// multi-line continuation
+		start := lineNo
+		for strings.HasSuffix(line, iniContinuation) && scanner.Scan() {
+			lineNo++
+			line = strings.TrimSuffix(line, iniContinuation) + strings.TrimSpace(scanner.Text())
+		}
+
+		if stringz.IsBlankString(line) || strings.ContainsAny(line[:1], iniCommentChars) {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("line:[%d] invalid section:[%s]", start, line)
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			section = splitIniKey(name)
+			if len(section) == 0 {
+				return fmt.Errorf("line:[%d] invalid section:[%s]", start, line)
+			}
+			if positions != nil {
+				putPosition(positions, section, Position{Line: start, Column: column}, false)
+			}
+
+			continue
+		}
+
+		index := strings.IndexAny(line, iniAssignChars)
+		if index <= 0 {
+			return fmt.Errorf("line:[%d] invalid key-value pair:[%s]", start, line)
+		}
+
+		segments := splitIniKey(line[:index])
+		if len(segments) == 0 {
+			return fmt.Errorf("line:[%d] invalid key:[%s]", start, line[:index])
+		}
+
+		value, err := parseIniValue(strings.TrimSpace(line[index+1:]))
+		if err != nil {
+			return fmt.Errorf("line:[%d] %w", start, err)
+		}
+
+		keys := append(append(make(collection.StringSlice, 0, len(section)+len(segments)), section...), segments...)
+		if err = putIniValue(ctx, keys, value); err != nil {
+			return fmt.Errorf("line:[%d] %w", start, err)
+		}
+		if positions != nil {
+			putPosition(positions, keys, Position{Line: start, Column: column}, false)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func splitIniKey(key string) collection.StringSlice {
+	segments := make(collection.StringSlice, 0)
+	for _, segment := range strings.Split(key, stringz.Dot) {
+		segment = strings.TrimSpace(segment)
+		if stringz.IsBlankString(segment) {
+			return nil
+		}
+		segments = append(segments, segment)
+	}
+
+	return segments
+}
+
+// parseIniValue unquotes the quoted value, or trims the inline comment of the unquoted value.
+func parseIniValue(value string) (string, error) {
+	if stringz.IsBlankString(value) {
+		return value, nil
+	}
+
+	quote := value[0]
+	if '"' != quote && '\'' != quote {
+		for i := 1; i < len(value); i++ {
+			if strings.ContainsRune(iniCommentChars, rune(value[i])) && (' ' == value[i-1] || '\t' == value[i-1]) {
+				return strings.TrimSpace(value[:i]), nil
+			}
+		}
+
+		return value, nil
+	}
+
+	var buf strings.Builder
+	for i := 1; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == quote:
+			if rest := strings.TrimSpace(value[i+1:]); stringz.IsNotBlankString(rest) && !strings.ContainsAny(rest[:1], iniCommentChars) {
+				return "", fmt.Errorf("unexpected content:[%s] after the quoted value", rest)
+			}
+
+			return buf.String(), nil
+		case ch == '\\' && '"' == quote && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				buf.WriteByte('\n')
+			case 't':
+				buf.WriteByte('\t')
+			default:
+				buf.WriteByte(value[i])
+			}
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated quoted value:[%s]", value)
+}
+
+// putIniValue puts the value into the nested keys, the duplicate keys are collected into a list.
+func putIniValue(ctx map[string]any, keys collection.StringSlice, value string) error {
+	current := ctx
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok {
+			child := make(map[string]any)
+			current[key] = child
+			current = child
+
+			continue
+		}
+
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key:[%s] is both a value and a section", key)
+		}
+		current = child
+	}
+
+	last := keys[len(keys)-1]
+	switch v := current[last].(type) {
+	case nil:
+		current[last] = value
+	case string:
+		current[last] = []any{v, value}
+	case []any:
+		current[last] = append(v, value)
+	default:
+		return fmt.Errorf("key:[%s] is both a value and a section", last)
+	}
+
+	return nil
+}
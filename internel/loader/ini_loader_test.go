@@ -0,0 +1,124 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+func TestIniConfigLoader_Load(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	testdataDir := filepath.Dir(testFile)
+
+	absPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/application.ini"))
+	badAbsPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/config.ini")) // not found
+
+	ctx := make(map[string]any)
+
+	type args struct {
+		path      string
+		targetPtr any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "loader#ini_ok",
+			args: args{
+				path:      absPath,
+				targetPtr: &ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader#ini_failed",
+			args: args{
+				path:      badAbsPath,
+				targetPtr: &ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icl := NewIniConfigLoader()
+			if err := icl.Load(tt.args.path, tt.args.targetPtr); (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIniConfigLoader_LoadMap(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	absPath := filepath.Clean(filepath.Join(filepath.Dir(testFile), "../../tests/testdata/application.ini"))
+
+	ctx := make(map[string]any)
+	if err := NewIniConfigLoader().LoadMap(absPath, ctx); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{name: "loader#ini_global", key: "name", want: "nemoapp"},
+		{name: "loader#ini_section", key: "server.port", want: "9527"},
+		{name: "loader#ini_quoted", key: "server.banner", want: `hello "nemo"`},
+		{name: "loader#ini_nested_section", key: "nemo.datasource.driver", want: "mysql"},
+		{name: "loader#ini_inline_comment", key: "nemo.datasource.host", want: "192.168.1.10"},
+		{name: "loader#ini_colon", key: "nemo.datasource.port", want: "3306"},
+		{name: "loader#ini_single_quoted", key: "nemo.datasource.password", want: "root;#"},
+		{name: "loader#ini_continuation", key: "nemo.datasource.dsn", want: "root:root@tcp(192.168.1.10:3306)/nemo?charset=utf8mb4"},
+		{name: "loader#ini_duplicate", key: "nemo.rabbitmq.hosts", want: []any{"192.168.1.11", "192.168.1.12", "192.168.1.13"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := mapz.NestedGet(ctx, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NestedGet() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIni_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "loader#ini_invalid_section", text: "[server\nport = 1"},
+		{name: "loader#ini_empty_section", text: "[]\nport = 1"},
+		{name: "loader#ini_invalid_pair", text: "[server]\nport"},
+		{name: "loader#ini_unterminated_quote", text: "banner = \"hello"},
+		{name: "loader#ini_value_and_section", text: "server = 1\n[server]\nport = 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := parseIni(strings.NewReader(tt.text), make(map[string]any), nil); err == nil {
+				t.Errorf("parseIni() error = nil, want error")
+			}
+		})
+	}
+}
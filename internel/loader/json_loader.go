@@ -0,0 +1,240 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+	"github.com/photowey/nemo/pkg/stringz"
+	"github.com/photowey/nemo/pkg/valuez"
+)
+
+// xxx.json
+
+const (
+	Json = "json"
+)
+
+const (
+	jsonStep     = 10000
+	jsonPriority = ordered.HighPriority + jsonStep*ordered.DefaultStep
+)
+
+var (
+	jsonSupportedConfigTypes = stringz.InitStringSlice(Json)
+)
+
+var (
+	_ ConfigLoader    = (*JsonConfigLoader)(nil)
+	_ PositionDecoder = (*JsonConfigLoader)(nil)
+)
+
+func init() {
+	_ = Register(NewJsonConfigLoader())
+}
+
+type JsonConfigLoader struct {
+}
+
+func NewJsonConfigLoader() ConfigLoader {
+	return &JsonConfigLoader{}
+}
+
+func (jcl *JsonConfigLoader) Supports(strategy string) bool {
+	return collection.ArrayContains(jsonSupportedConfigTypes, strategy)
+}
+
+func (jcl *JsonConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(jsonSupportedConfigTypes)), jsonSupportedConfigTypes...)
+}
+
+func (jcl *JsonConfigLoader) Order() int64 {
+	return jsonPriority
+}
+
+func (jcl *JsonConfigLoader) Name() string {
+	return Json
+}
+
+func (jcl *JsonConfigLoader) Load(path string, targetPtr any) error {
+	if valuez.IsNil(targetPtr) {
+		return fmt.Errorf("nemo: load json config file, targetPtr can't be nil")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return decodeJson(file, targetPtr)
+}
+
+func (jcl *JsonConfigLoader) LoadMap(path string, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: load json config file, ctx can't be nil")
+	}
+
+	return decodeFile(path, ctx, jcl.Decode)
+}
+
+func (jcl *JsonConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode json config, ctx can't be nil")
+	}
+
+	return decodeJson(reader, &ctx)
+}
+
+// ----------------------------------------------------------------
+
+// decodeJson decodes the numbers as json.Number, then converts them into int64 | float64.
+func decodeJson(reader io.Reader, targetPtr any) error {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(targetPtr); err != nil {
+		return err
+	}
+
+	if ctx, ok := targetPtr.(*map[string]any); ok {
+		normalizeJsonNumbers(*ctx)
+	}
+
+	return nil
+}
+
+func normalizeJsonNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+
+		return v.String()
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeJsonNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJsonNumbers(item)
+		}
+	}
+
+	return value
+}
+
+// DecodePositions walks the tokens of the json content, the position of the key is the opening quote.
+func (jcl *JsonConfigLoader) DecodePositions(reader io.Reader) (map[string]Position, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJsonPositions(content)
+}
+
+// ----------------------------------------------------------------
+
+type jsonFrame struct {
+	object    bool
+	recorded  bool // the keys inside the lists are skipped
+	prefix    string
+	expectKey bool
+	key       string
+}
+
+func scanJsonPositions(content []byte) (map[string]Position, error) {
+	positions := make(map[string]Position)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	frames := make([]*jsonFrame, 0)
+
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return positions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var top *jsonFrame
+		if len(frames) > 0 {
+			top = frames[len(frames)-1]
+		}
+
+		if top != nil && top.object && top.expectKey {
+			if key, ok := token.(string); ok {
+				top.key = key
+				top.expectKey = false
+				if top.recorded {
+					positions[joinKey(top.prefix, key)] = jsonPosition(content, offset)
+				}
+
+				continue
+			}
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			frame := &jsonFrame{object: json.Delim('{') == token, expectKey: true}
+			switch {
+			case top == nil:
+				frame.recorded = true
+			case top.object:
+				frame.recorded = top.recorded
+				frame.prefix = joinKey(top.prefix, top.key)
+			}
+			frame.recorded = frame.recorded && frame.object
+			frames = append(frames, frame)
+		case json.Delim('}'), json.Delim(']'):
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				frames[len(frames)-1].expectKey = true
+			}
+		default:
+			if top != nil {
+				top.expectKey = true
+			}
+		}
+	}
+}
+
+// jsonPosition skips the separators after the offset, then counts the lines and the columns.
+func jsonPosition(content []byte, offset int64) Position {
+	index := int(offset)
+	for index < len(content) && strings.ContainsRune(" \t\r\n,:", rune(content[index])) {
+		index++
+	}
+
+	line := 1 + strings.Count(string(content[:index]), "\n")
+	column := index - strings.LastIndex(string(content[:index]), "\n")
+
+	return Position{Line: line, Column: column}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+func TestJsonConfigLoader_Load(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	testdataDir := filepath.Dir(testFile)
+
+	absPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/application.json"))
+	badAbsPath := filepath.Clean(filepath.Join(testdataDir, "../../tests/testdata/config.json")) // not found
+
+	ctx := make(map[string]any)
+
+	type args struct {
+		path      string
+		targetPtr any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "loader#json_ok",
+			args: args{
+				path:      absPath,
+				targetPtr: &ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader#json_failed",
+			args: args{
+				path:      badAbsPath,
+				targetPtr: &ctx,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jcl := NewJsonConfigLoader()
+			if err := jcl.Load(tt.args.path, tt.args.targetPtr); (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJsonConfigLoader_LoadMap_Numbers(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	absPath := filepath.Clean(filepath.Join(filepath.Dir(testFile), "../../tests/testdata/application.json"))
+
+	ctx := make(map[string]any)
+	if err := NewJsonConfigLoader().LoadMap(absPath, ctx); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{name: "loader#json_int64", key: "nemo.datasource.port", want: int64(3306)},
+		{name: "loader#json_int64_big", key: "nemo.datasource.big", want: int64(9007199254740993)},
+		{name: "loader#json_float64", key: "nemo.datasource.ratio", want: 0.75},
+		{name: "loader#json_bool", key: "nemo.rabbitmq.enabled", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := mapz.NestedGet(ctx, tt.key); got != tt.want {
+				t.Errorf("NestedGet() got = %v(%T), want %v(%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
@@ -17,14 +17,37 @@
 package loader
 
 import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/photowey/nemo/pkg/collection"
 	"github.com/photowey/nemo/pkg/ordered"
 	"github.com/photowey/nemo/pkg/strategy"
 )
 
+// ConfigLoader | the LoadMap and the Decode emit the canonical tree: map[string]any | []any | scalars.
 type ConfigLoader interface {
 	ordered.Ordered
 	strategy.Supporter
 	Name() string
+	Extensions() collection.StringSlice // the config types(file extensions) of the loader, e.g.: yaml | yml
 	Load(path string, targetPtr any) error
 	LoadMap(path string, ctx map[string]any) error
+	Decode(reader io.Reader, ctx map[string]any) error // decodes the config content, e.g.: embed.FS | http body | bytes
+}
+
+// decodeFile opens the config file, then decodes it into the ctx.
+func decodeFile(path string, ctx map[string]any, decode func(reader io.Reader, ctx map[string]any) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = decode(file, ctx); err != nil {
+		return fmt.Errorf("nemo: decode config file:[%s] failed, %w", path, err)
+	}
+
+	return nil
 }
@@ -17,7 +17,20 @@
 package loader
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/photowey/nemo/pkg/collection"
 	"github.com/photowey/nemo/pkg/mapz"
+	"github.com/photowey/nemo/pkg/valuez"
+)
+
+var (
+	ErrLoaderNil      = errors.New("nemo: config loader can't be nil")
+	ErrLoaderConflict = errors.New("nemo: config loader conflict")
+	ErrLoaderNotFound = errors.New("nemo: config loader not found")
 )
 
 var (
@@ -29,37 +42,125 @@ var (
 )
 
 type Registry interface {
-	Register(loader ConfigLoader)
+	Register(loader ConfigLoader) error
+	Unregister(name string) bool
 	Contains(name string) bool
 	Loader(name string) (ConfigLoader, bool)
 	Loaders() []ConfigLoader
+	Select(extension string) (ConfigLoader, bool)
+	Extensions() collection.StringSlice
 }
 
 type registry struct {
+	sync.RWMutex
 	loaderMap map[string]ConfigLoader
 }
 
-func (r registry) Register(loader ConfigLoader) {
+// Register registers the loader, replacing the one with the same name,
+// the loader that claims the same extension at the equal priority of another one is rejected.
+func (r *registry) Register(loader ConfigLoader) error {
+	if valuez.IsNil(loader) {
+		return ErrLoaderNil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	for name, registered := range r.loaderMap {
+		if name == loader.Name() || registered.Order() != loader.Order() {
+			continue
+		}
+
+		for _, extension := range loader.Extensions() {
+			if collection.ArrayContains(registered.Extensions(), extension) {
+				return fmt.Errorf("%w: loader:[%s] and loader:[%s] claim the extension:[%s] at the equal priority:[%d]",
+					ErrLoaderConflict, loader.Name(), name, extension, loader.Order())
+			}
+		}
+	}
+
 	r.loaderMap[loader.Name()] = loader
+
+	return nil
 }
 
-func (r registry) Contains(name string) bool {
+func (r *registry) Unregister(name string) bool {
+	r.Lock()
+	defer r.Unlock()
+
 	_, ok := r.loaderMap[name]
+	delete(r.loaderMap, name)
+
 	return ok
 }
 
-func (r registry) Loader(name string) (ConfigLoader, bool) {
+func (r *registry) Contains(name string) bool {
+	_, ok := r.Loader(name)
+
+	return ok
+}
+
+func (r *registry) Loader(name string) (ConfigLoader, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
 	v, ok := r.loaderMap[name]
 
 	return v, ok
 }
 
-func (r registry) Loaders() []ConfigLoader {
-	return mapz.Values[string, ConfigLoader](r.loaderMap)
+// Loaders returns the all loaders, ordered by the priority, then the name.
+func (r *registry) Loaders() []ConfigLoader {
+	r.RLock()
+	loaders := mapz.Values[string, ConfigLoader](r.loaderMap)
+	r.RUnlock()
+
+	sort.SliceStable(loaders, func(i, j int) bool {
+		if loaders[i].Order() != loaders[j].Order() {
+			return loaders[i].Order() < loaders[j].Order()
+		}
+
+		return loaders[i].Name() < loaders[j].Name()
+	})
+
+	return loaders
+}
+
+// Select returns the loader of the extension, the smaller the order, the higher the priority.
+func (r *registry) Select(extension string) (ConfigLoader, bool) {
+	for _, loader := range r.Loaders() {
+		if loader.Supports(extension) {
+			return loader, true
+		}
+	}
+
+	return nil, false
 }
 
-func Register(loader ConfigLoader) {
-	_registry.Register(loader)
+// Extensions returns the extensions of the all loaders, ordered by the priority of the loader.
+func (r *registry) Extensions() collection.StringSlice {
+	loaders := r.Loaders()
+
+	extensions := make(collection.StringSlice, 0, len(loaders))
+	for _, loader := range loaders {
+		for _, extension := range loader.Extensions() {
+			if collection.ArrayNotContains(extensions, extension) {
+				extensions = append(extensions, extension)
+			}
+		}
+	}
+
+	return extensions
+}
+
+// ----------------------------------------------------------------
+
+func Register(loader ConfigLoader) error {
+	return _registry.Register(loader)
+}
+
+func Unregister(name string) bool {
+	return _registry.Unregister(name)
 }
 
 func Contains(name string) bool {
@@ -67,11 +168,19 @@ func Contains(name string) bool {
 }
 
 func Loader(name string) (ConfigLoader, bool) {
-	v, ok := _registry.loaderMap[name]
-
-	return v, ok
+	return _registry.Loader(name)
 }
 
 func Loaders() []ConfigLoader {
 	return _registry.Loaders()
 }
+
+// Select returns the loader of the extension with the highest priority.
+func Select(extension string) (ConfigLoader, bool) {
+	return _registry.Select(extension)
+}
+
+// Extensions returns the config types supported by the registered loaders.
+func Extensions() collection.StringSlice {
+	return _registry.Extensions()
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+)
+
+// testLoader claims the extensions at the order, loads nothing.
+type testLoader struct {
+	ConfigLoader
+	name       string
+	order      int64
+	extensions collection.StringSlice
+}
+
+func (l testLoader) Name() string {
+	return l.name
+}
+
+func (l testLoader) Order() int64 {
+	return l.order
+}
+
+func (l testLoader) Extensions() collection.StringSlice {
+	return l.extensions
+}
+
+func (l testLoader) Supports(strategy string) bool {
+	return collection.ArrayContains(l.extensions, strategy)
+}
+
+func newTestRegistry() *registry {
+	return &registry{
+		loaderMap: make(map[string]ConfigLoader),
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	want := collection.StringSlice{Yaml, Yml, Toml, Properties, Json, Ini, Dotenv, Hcl}
+	if got := Extensions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Extensions() got = %v, want %v", got, want)
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		loaders []ConfigLoader
+		wantErr error
+	}{
+		{
+			name:    "registry#Register_nil",
+			loaders: []ConfigLoader{nil},
+			wantErr: ErrLoaderNil,
+		},
+		{
+			name: "registry#Register_conflict",
+			loaders: []ConfigLoader{
+				testLoader{name: "a", order: ordered.DefaultPriority, extensions: collection.StringSlice{"x", "y"}},
+				testLoader{name: "b", order: ordered.DefaultPriority, extensions: collection.StringSlice{"y"}},
+			},
+			wantErr: ErrLoaderConflict,
+		},
+		{
+			name: "registry#Register_different_priority",
+			loaders: []ConfigLoader{
+				testLoader{name: "a", order: ordered.DefaultPriority, extensions: collection.StringSlice{"x"}},
+				testLoader{name: "b", order: ordered.DefaultPriority + 1, extensions: collection.StringSlice{"x"}},
+			},
+		},
+		{
+			name: "registry#Register_replace_same_name",
+			loaders: []ConfigLoader{
+				testLoader{name: "a", order: ordered.DefaultPriority, extensions: collection.StringSlice{"x"}},
+				testLoader{name: "a", order: ordered.DefaultPriority, extensions: collection.StringSlice{"x"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRegistry()
+
+			var err error
+			for _, loader := range tt.loaders {
+				if err = r.Register(loader); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistry_Select(t *testing.T) {
+	r := newTestRegistry()
+	low := testLoader{name: "low", order: ordered.DefaultPriority + 1, extensions: collection.StringSlice{"x"}}
+	high := testLoader{name: "high", order: ordered.DefaultPriority, extensions: collection.StringSlice{"x", "y"}}
+	for _, loader := range []ConfigLoader{low, high} {
+		if err := r.Register(loader); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	if got, ok := r.Select("x"); !ok || got.Name() != "high" {
+		t.Errorf("Select() got = %v, want %v", got, "high")
+	}
+
+	if !r.Unregister("high") || r.Unregister("high") {
+		t.Errorf("Unregister() should remove the loader once")
+	}
+	if got, ok := r.Select("x"); !ok || got.Name() != "low" {
+		t.Errorf("Select() got = %v, want %v", got, "low")
+	}
+	if _, ok := r.Select("y"); ok {
+		t.Errorf("Select() the unregistered extension should not be found")
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	r := newTestRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("loader-%d", i)
+			_ = r.Register(testLoader{name: name, order: int64(i), extensions: collection.StringSlice{"x"}})
+			_, _ = r.Select("x")
+			_ = r.Extensions()
+			r.Unregister(name)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := r.Loaders(); len(got) != 0 {
+		t.Errorf("Loaders() got = %v, want empty", got)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigLoader_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		loader  ConfigLoader
+		content string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "loader#Decode_yaml",
+			loader:  NewYamlConfigLoader(),
+			content: "server:\n  port: 8080\nservers:\n  - host: a.com\n    ports: {1: http}\n",
+			want: map[string]any{
+				"server":  map[string]any{"port": 8080},
+				"servers": []any{map[string]any{"host": "a.com", "ports": map[string]any{"1": "http"}}},
+			},
+		},
+		{
+			name:    "loader#Decode_toml",
+			loader:  NewTomlConfigLoader(),
+			content: "[server]\nport = 8080\n[[servers]]\nhost = \"a.com\"\n",
+			want: map[string]any{
+				"server":  map[string]any{"port": int64(8080)},
+				"servers": []any{map[string]any{"host": "a.com"}},
+			},
+		},
+		{
+			name:    "loader#Decode_properties",
+			loader:  NewPropertiesConfigLoader(),
+			content: "server.port=8080\n",
+			want:    map[string]any{"server": map[string]any{"port": "8080"}},
+		},
+		{
+			name:    "loader#Decode_json",
+			loader:  NewJsonConfigLoader(),
+			content: `{"server": {"port": 8080}}`,
+			want:    map[string]any{"server": map[string]any{"port": int64(8080)}},
+		},
+		{
+			name:    "loader#Decode_ini",
+			loader:  NewIniConfigLoader(),
+			content: "[server]\nport = 8080\n",
+			want:    map[string]any{"server": map[string]any{"port": "8080"}},
+		},
+		{
+			name:    "loader#Decode_dotenv",
+			loader:  NewDotenvConfigLoader(),
+			content: "SERVER_PORT=8080\n",
+			want:    map[string]any{"SERVER_PORT": "8080"},
+		},
+		{
+			name:    "loader#Decode_hcl",
+			loader:  NewHclConfigLoader(),
+			content: "server {\n  port = 8080\n}\n",
+			want:    map[string]any{"server": map[string]any{"port": int64(8080)}},
+		},
+		{
+			name:    "loader#Decode_json_failed",
+			loader:  NewJsonConfigLoader(),
+			content: `{"server": `,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := make(map[string]any)
+			err := tt.loader.Decode(strings.NewReader(tt.content), ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(ctx, tt.want) {
+				t.Errorf("Decode() got = %v, want %v", ctx, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		loader  ConfigLoader
+		content string
+		want    map[string]Position
+		wantOk  bool
+	}{
+		{
+			name:    "loader#DecodePositions_yaml",
+			loader:  NewYamlConfigLoader(),
+			content: "# comment\nserver:\n  port: 8080 # port\n  banner: |\n    a: b\nservers:\n  - host: a.com\n\"app\":\n  name: nemo\n",
+			want: map[string]Position{
+				"server":        {Line: 2, Column: 1},
+				"server.port":   {Line: 3, Column: 3},
+				"server.banner": {Line: 4, Column: 3},
+				"servers":       {Line: 6, Column: 1},
+				"app":           {Line: 8, Column: 1},
+				"app.name":      {Line: 9, Column: 3},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "loader#DecodePositions_json",
+			loader:  NewJsonConfigLoader(),
+			content: "{\n  \"server\": {\n    \"port\": 8080,\n    \"hosts\": [{\"a\": 1}]\n  },\n  \"name\": \"nemo\"\n}",
+			want: map[string]Position{
+				"server":       {Line: 2, Column: 3},
+				"server.port":  {Line: 3, Column: 5},
+				"server.hosts": {Line: 4, Column: 5},
+				"name":         {Line: 6, Column: 3},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "loader#DecodePositions_properties",
+			loader:  NewPropertiesConfigLoader(),
+			content: "# comment\nserver.port=8080\n  server.host = a.com \\\n  b.com\nservers[0].host=a.com\nserver.port=9527\n",
+			want: map[string]Position{
+				"server":      {Line: 2, Column: 1},
+				"server.port": {Line: 6, Column: 1},
+				"server.host": {Line: 3, Column: 3},
+				"servers":     {Line: 5, Column: 1},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "loader#DecodePositions_ini",
+			loader:  NewIniConfigLoader(),
+			content: "; comment\n[server]\nport = 8080\n  hosts = a.com\nhosts = b.com\n",
+			want: map[string]Position{
+				"server":       {Line: 2, Column: 1},
+				"server.port":  {Line: 3, Column: 1},
+				"server.hosts": {Line: 4, Column: 3},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "loader#DecodePositions_dotenv",
+			loader:  NewDotenvConfigLoader(),
+			content: "# comment\nexport DB_HOST=a.com\n  DB_NAME=nemo\n",
+			want: map[string]Position{
+				"DB_HOST": {Line: 2, Column: 8},
+				"DB_NAME": {Line: 3, Column: 3},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "loader#DecodePositions_toml",
+			loader:  NewTomlConfigLoader(),
+			content: "[server]\nport = 8080\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := DecodePositions(tt.loader, strings.NewReader(tt.content))
+			if err != nil || ok != tt.wantOk {
+				t.Fatalf("DecodePositions() ok = %v, error = %v, wantOk %v", ok, err, tt.wantOk)
+			}
+			if tt.wantOk && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodePositions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
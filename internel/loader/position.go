@@ -0,0 +1,75 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"io"
+	"strings"
+
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+// Position | the 1-based line and column of the key in the config content.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// PositionDecoder | the optional capability of the ConfigLoader, decodes the positions of the nested map keys,
+// e.g.: server.port -> {3 3}, the keys inside the lists are skipped.
+type PositionDecoder interface {
+	DecodePositions(reader io.Reader) (map[string]Position, error)
+}
+
+// DecodePositions decodes the positions of the keys by the loader, if it's a PositionDecoder.
+func DecodePositions(handler ConfigLoader, reader io.Reader) (map[string]Position, bool, error) {
+	decoder, ok := handler.(PositionDecoder)
+	if !ok {
+		return nil, false, nil
+	}
+
+	positions, err := decoder.DecodePositions(reader)
+
+	return positions, true, err
+}
+
+// ----------------------------------------------------------------
+
+// indentOf | the width of the leading spaces | tabs of the line.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+func joinKey(prefix, key string) string {
+	if stringz.IsBlankString(prefix) {
+		return key
+	}
+
+	return stringz.Concat(prefix, stringz.Dot, key)
+}
+
+// putPosition records the position of the nested key, the parent keys keep their first positions,
+// the leaf key takes the last one, the later value wins.
+func putPosition(positions map[string]Position, segments []string, position Position, leaf bool) {
+	key := ""
+	for i, segment := range segments {
+		key = joinKey(key, segment)
+		if _, ok := positions[key]; !ok || (leaf && i == len(segments)-1) {
+			positions[key] = position
+		}
+	}
+}
@@ -17,7 +17,11 @@
 package loader
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/magiconair/properties"
 	"github.com/mitchellh/mapstructure"
@@ -33,6 +37,10 @@ const (
 	Properties = "properties"
 )
 
+const (
+	propertiesMaxListIndex = 1 << 10
+)
+
 const (
 	propertiesStep     = 1000
 	propertiesPriority = ordered.HighPriority + propertiesStep*ordered.DefaultStep
@@ -43,11 +51,12 @@ var (
 )
 
 var (
-	_ ConfigLoader = (*PropertiesConfigLoader)(nil)
+	_ ConfigLoader    = (*PropertiesConfigLoader)(nil)
+	_ PositionDecoder = (*PropertiesConfigLoader)(nil)
 )
 
 func init() {
-	Register(NewPropertiesConfigLoader())
+	_ = Register(NewPropertiesConfigLoader())
 }
 
 type PropertiesConfigLoader struct {
@@ -61,6 +70,10 @@ func (pcl *PropertiesConfigLoader) Supports(strategy string) bool {
 	return collection.ArrayContains(propertiesSupportedConfigTypes, strategy)
 }
 
+func (pcl *PropertiesConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(propertiesSupportedConfigTypes)), propertiesSupportedConfigTypes...)
+}
+
 func (pcl *PropertiesConfigLoader) Order() int64 {
 	return propertiesPriority
 }
@@ -88,15 +101,184 @@ func (pcl *PropertiesConfigLoader) LoadMap(path string, ctx map[string]any) erro
 		return fmt.Errorf("nemo: load properties config file, ctx can't be nil")
 	}
 
-	ppt, err := properties.LoadFile(path, properties.UTF8)
+	return decodeFile(path, ctx, pcl.Decode)
+}
+
+func (pcl *PropertiesConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode properties config, ctx can't be nil")
+	}
+
+	bytes, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	// the ${...} placeholders are kept, they're resolved by the environment across the all sources.
+	pl := &properties.Loader{Encoding: properties.UTF8, DisableExpansion: true}
+	ppt, err := pl.LoadBytes(bytes)
 	if err != nil {
 		return err
 	}
 
 	for _, key := range ppt.Keys() {
 		value, _ := ppt.Get(key)
-		ctx[key] = value
+		if err = putProperty(ctx, key, value); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// ----------------------------------------------------------------
+
+// putProperty expands the dotted key into the nested maps, e.g.: server.port | servers[0].host | hosts[1]
+func putProperty(ctx map[string]any, key string, value string) error {
+	segments, err := parsePropertyKey(key)
+	if err != nil {
+		return err
+	}
+	if _, ok := segments[0].(string); !ok {
+		return fmt.Errorf("invalid key:[%s], the list index can't be the first segment", key)
+	}
+
+	_, err = putPropertySegments(ctx, segments, value, key)
+
+	return err
+}
+
+// parsePropertyKey splits the key into the segments, the string is the map key, the int is the list index.
+func parsePropertyKey(key string) ([]any, error) {
+	segments := make([]any, 0)
+	for _, part := range strings.Split(key, stringz.Dot) {
+		name := part
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			name = part[:open]
+		}
+		if stringz.IsNotBlankString(name) {
+			segments = append(segments, name)
+		}
+
+		rest := part[len(name):]
+		if stringz.IsBlankString(name) && stringz.IsBlankString(rest) {
+			return nil, fmt.Errorf("invalid key:[%s], empty segment", key)
+		}
+
+		for stringz.IsNotBlankString(rest) {
+			end := strings.IndexByte(rest, ']')
+			if '[' != rest[0] || end < 0 {
+				return nil, fmt.Errorf("invalid key:[%s], malformed index", key)
+			}
+
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 || index > propertiesMaxListIndex {
+				return nil, fmt.Errorf("invalid key:[%s], invalid index:[%s]", key, rest[1:end])
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		}
+	}
+
+	return segments, nil
+}
+
+func putPropertySegments(container any, segments []any, value string, key string) (any, error) {
+	if len(segments) == 0 {
+		if container != nil {
+			return nil, fmt.Errorf("key:[%s] is both a value and a map/list", key)
+		}
+
+		return value, nil
+	}
+
+	switch segment := segments[0].(type) {
+	case int:
+		list, ok := container.([]any)
+		if !ok && container != nil {
+			return nil, fmt.Errorf("key:[%s] is both a value/map and a list", key)
+		}
+		for len(list) <= segment {
+			list = append(list, nil)
+		}
+
+		child, err := putPropertySegments(list[segment], segments[1:], value, key)
+		if err != nil {
+			return nil, err
+		}
+		list[segment] = child
+
+		return list, nil
+	default:
+		ctx, ok := container.(map[string]any)
+		if !ok {
+			if container != nil {
+				return nil, fmt.Errorf("key:[%s] is both a value/list and a map", key)
+			}
+			ctx = make(map[string]any)
+		}
+
+		name := segment.(string)
+		child, err := putPropertySegments(ctx[name], segments[1:], value, key)
+		if err != nil {
+			return nil, err
+		}
+		ctx[name] = child
+
+		return ctx, nil
+	}
+}
+
+// ----------------------------------------------------------------
+
+// DecodePositions scans the key-value lines, the continuation lines are skipped,
+// the list indexes end the keys, e.g.: servers[0].host -> servers
+func (pcl *PropertiesConfigLoader) DecodePositions(reader io.Reader) (map[string]Position, error) {
+	positions := make(map[string]Position)
+	scanner := bufio.NewScanner(reader)
+	lineNo := 0
+	continued := false
+
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		previous := continued
+		continued = strings.HasSuffix(trimmed, `\`) && 1 == (len(trimmed)-len(strings.TrimRight(trimmed, `\`)))%2
+		if previous || stringz.IsBlankString(trimmed) || strings.ContainsAny(trimmed[:1], "#!") {
+			continue
+		}
+
+		segments, err := parsePropertyKey(propertyKeyOf(trimmed))
+		if err != nil {
+			continue
+		}
+
+		keys := make([]string, 0, len(segments))
+		for _, segment := range segments {
+			if name, ok := segment.(string); ok {
+				keys = append(keys, name)
+				continue
+			}
+			break
+		}
+		putPosition(positions, keys, Position{Line: lineNo, Column: indentOf(line) + 1}, len(keys) == len(segments))
+	}
+
+	return positions, scanner.Err()
+}
+
+// propertyKeyOf | the key ends with the first unescaped `=` | `:` | whitespace.
+func propertyKeyOf(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':', ' ', '\t', '\f':
+			return line[:i]
+		}
+	}
+
+	return line
+}
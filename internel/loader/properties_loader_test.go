@@ -18,7 +18,11 @@ package loader
 
 import (
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/photowey/nemo/pkg/mapz"
 )
 
 func TestPropertiesConfigLoader_Load(t *testing.T) {
@@ -65,3 +69,86 @@ func TestPropertiesConfigLoader_Load(t *testing.T) {
 		})
 	}
 }
+
+func TestPropertiesConfigLoader_LoadMap_Nested(t *testing.T) {
+	testFile := determineTestSourceFilePath()
+	absPath := filepath.Clean(filepath.Join(filepath.Dir(testFile), "../../tests/testdata/application.properties"))
+
+	ctx := make(map[string]any)
+	if err := NewPropertiesConfigLoader().LoadMap(absPath, ctx); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want any
+	}{
+		{name: "loader#properties_nested", key: "server.port", want: "9527"},
+		{name: "loader#properties_nested_deep", key: "nemo.datasource.host", want: `"192.168.1.10"`},
+		{name: "loader#properties_list_of_maps", key: "nemo.servers", want: []any{
+			map[string]any{"host": "a.com", "port": "80"},
+			map[string]any{"host": "b.com"},
+		}},
+		{name: "loader#properties_list", key: "nemo.hosts", want: []any{"c.com", "d.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := mapz.NestedGet(ctx, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NestedGet() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := ctx["server.port"]; ok {
+		t.Errorf("LoadMap() the dotted key should be expanded")
+	}
+}
+
+func TestPropertiesConfigLoader_Decode_Placeholders(t *testing.T) {
+	content := "server.port=8080\na.url=http://${server.host}:${server.port}\napp.version=${app.version:1.0.0}\n"
+
+	ctx := make(map[string]any)
+	if err := NewPropertiesConfigLoader().Decode(strings.NewReader(content), ctx); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	want := map[string]any{
+		"server": map[string]any{"port": "8080"},
+		"a":      map[string]any{"url": "http://${server.host}:${server.port}"},
+		"app":    map[string]any{"version": "${app.version:1.0.0}"},
+	}
+	if !reflect.DeepEqual(ctx, want) {
+		t.Errorf("Decode() got = %v, want %v", ctx, want)
+	}
+}
+
+func TestPutProperty_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+	}{
+		{name: "properties#empty_segment", keys: []string{"server..port"}},
+		{name: "properties#leading_index", keys: []string{"[0].host"}},
+		{name: "properties#malformed_index", keys: []string{"servers[0.host"}},
+		{name: "properties#invalid_index", keys: []string{"servers[x].host"}},
+		{name: "properties#value_and_map", keys: []string{"server", "server.port"}},
+		{name: "properties#map_and_value", keys: []string{"server.port", "server"}},
+		{name: "properties#map_and_list", keys: []string{"server.port", "server[0]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := make(map[string]any)
+
+			var err error
+			for _, key := range tt.keys {
+				if err = putProperty(ctx, key, "value"); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				t.Errorf("putProperty() error = nil, want error")
+			}
+		})
+	}
+}
@@ -18,9 +18,11 @@ package loader
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/BurntSushi/toml"
 	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
 	"github.com/photowey/nemo/pkg/ordered"
 	"github.com/photowey/nemo/pkg/stringz"
 	"github.com/photowey/nemo/pkg/valuez"
@@ -46,7 +48,7 @@ var (
 )
 
 func init() {
-	Register(NewTomlConfigLoader())
+	_ = Register(NewTomlConfigLoader())
 }
 
 type TomlConfigLoader struct {
@@ -60,6 +62,10 @@ func (tcl *TomlConfigLoader) Supports(strategy string) bool {
 	return collection.ArrayContains(tomlSupportedConfigTypes, strategy)
 }
 
+func (tcl *TomlConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(tomlSupportedConfigTypes)), tomlSupportedConfigTypes...)
+}
+
 func (tcl *TomlConfigLoader) Order() int64 {
 	return tomlPriority
 }
@@ -73,9 +79,15 @@ func (tcl *TomlConfigLoader) Load(path string, targetPtr any) error {
 		return fmt.Errorf("nemo: load toml config file, targetPtr can't be nil")
 	}
 
-	_, err := toml.DecodeFile(path, targetPtr)
+	if _, err := toml.DecodeFile(path, targetPtr); err != nil {
+		return err
+	}
+
+	if ctx, ok := targetPtr.(*map[string]any); ok {
+		mapz.CanonicalizeMap(*ctx)
+	}
 
-	return err
+	return nil
 }
 
 func (tcl *TomlConfigLoader) LoadMap(path string, ctx map[string]any) error {
@@ -83,7 +95,20 @@ func (tcl *TomlConfigLoader) LoadMap(path string, ctx map[string]any) error {
 		return fmt.Errorf("nemo: load toml config file, ctx can't be nil")
 	}
 
-	_, err := toml.DecodeFile(path, &ctx)
+	return decodeFile(path, ctx, tcl.Decode)
+}
+
+func (tcl *TomlConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode toml config, ctx can't be nil")
+	}
+
+	if _, err := toml.NewDecoder(reader).Decode(&ctx); err != nil {
+		return err
+	}
+
+	// the array of tables: []map[string]interface{}
+	mapz.CanonicalizeMap(ctx)
 
-	return err
+	return nil
 }
@@ -18,9 +18,12 @@ package loader
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
 	"github.com/photowey/nemo/pkg/ordered"
 	"github.com/photowey/nemo/pkg/stringz"
 	"github.com/photowey/nemo/pkg/valuez"
@@ -44,11 +47,12 @@ var (
 )
 
 var (
-	_ ConfigLoader = (*YamlConfigLoader)(nil)
+	_ ConfigLoader    = (*YamlConfigLoader)(nil)
+	_ PositionDecoder = (*YamlConfigLoader)(nil)
 )
 
 func init() {
-	Register(NewYamlConfigLoader())
+	_ = Register(NewYamlConfigLoader())
 }
 
 type YamlConfigLoader struct {
@@ -69,6 +73,10 @@ func (ycl *YamlConfigLoader) Supports(strategy string) bool {
 	return collection.ArrayContains(ycl.types, strategy)
 }
 
+func (ycl *YamlConfigLoader) Extensions() collection.StringSlice {
+	return append(make(collection.StringSlice, 0, len(ycl.types)), ycl.types...)
+}
+
 func (ycl *YamlConfigLoader) Order() int64 {
 	return ycl.priority
 }
@@ -87,7 +95,15 @@ func (ycl *YamlConfigLoader) Load(path string, targetPtr any) error {
 		return err
 	}
 
-	return yaml.Unmarshal(bytes, targetPtr)
+	if err = yaml.Unmarshal(bytes, targetPtr); err != nil {
+		return err
+	}
+
+	if ctx, ok := targetPtr.(*map[string]any); ok {
+		mapz.CanonicalizeMap(*ctx)
+	}
+
+	return nil
 }
 
 func (ycl *YamlConfigLoader) LoadMap(path string, ctx map[string]any) error {
@@ -95,10 +111,128 @@ func (ycl *YamlConfigLoader) LoadMap(path string, ctx map[string]any) error {
 		return fmt.Errorf("nemo: load yaml(yml) config file, ctx can't be nil")
 	}
 
-	bytes, err := os.ReadFile(path)
+	return decodeFile(path, ctx, ycl.Decode)
+}
+
+func (ycl *YamlConfigLoader) Decode(reader io.Reader, ctx map[string]any) error {
+	if valuez.IsNil(ctx) {
+		return fmt.Errorf("nemo: decode yaml(yml) config, ctx can't be nil")
+	}
+
+	bytes, err := io.ReadAll(reader)
 	if err != nil {
 		return err
 	}
 
-	return yaml.Unmarshal(bytes, &ctx)
+	if err = yaml.Unmarshal(bytes, &ctx); err != nil {
+		return err
+	}
+
+	// yaml.v2: the nested maps are map[interface{}]interface{}
+	mapz.CanonicalizeMap(ctx)
+
+	return nil
+}
+
+// DecodePositions scans the block mappings by the indents, the flow mappings and the anchors are skipped.
+func (ycl *YamlConfigLoader) DecodePositions(reader io.Reader) (map[string]Position, error) {
+	bytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanYamlPositions(string(bytes)), nil
+}
+
+// ----------------------------------------------------------------
+
+type yamlKey struct {
+	indent int
+	key    string
+}
+
+func scanYamlPositions(content string) map[string]Position {
+	positions := make(map[string]Position)
+	parents := make([]yamlKey, 0)
+	skipIndent := -1 // the block scalar | the list items, the deeper lines are skipped
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if stringz.IsBlankString(trimmed) || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if "---" == trimmed || "..." == trimmed {
+			parents = parents[:0]
+			skipIndent = -1
+			continue
+		}
+
+		indent := indentOf(line)
+		isItem := "-" == trimmed || strings.HasPrefix(trimmed, "- ")
+		if skipIndent >= 0 {
+			if indent > skipIndent || (indent == skipIndent && isItem) {
+				continue
+			}
+			skipIndent = -1
+		}
+		if isItem {
+			skipIndent = indent
+			continue
+		}
+
+		key, value, ok := splitYamlKey(trimmed)
+		if !ok {
+			continue
+		}
+
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+
+		prefix := ""
+		for _, parent := range parents {
+			prefix = joinKey(prefix, parent.key)
+		}
+		positions[joinKey(prefix, key)] = Position{Line: i + 1, Column: indent + 1}
+
+		switch {
+		case stringz.IsBlankString(value):
+			parents = append(parents, yamlKey{indent: indent, key: key})
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			skipIndent = indent
+		}
+	}
+
+	return positions
+}
+
+// splitYamlKey splits the `key: value` line, the quoted key is unquoted, the comment of the value is trimmed.
+func splitYamlKey(line string) (string, string, bool) {
+	index := -1
+	if quote := line[0]; '"' == quote || '\'' == quote {
+		end := strings.IndexByte(line[1:], quote)
+		if end < 0 || !strings.HasPrefix(line[end+2:], ":") {
+			return "", "", false
+		}
+		index = end + 2
+	} else {
+		for j := 0; j < len(line); j++ {
+			if ':' == line[j] && (j == len(line)-1 || ' ' == line[j+1] || '\t' == line[j+1]) {
+				index = j
+				break
+			}
+		}
+	}
+	if index <= 0 {
+		return "", "", false
+	}
+
+	key := strings.Trim(strings.TrimSpace(line[:index]), `"'`)
+	value := strings.TrimSpace(line[index+1:])
+	if strings.HasPrefix(value, "#") {
+		value = ""
+	}
+
+	return key, value, stringz.IsNotBlankString(key)
 }
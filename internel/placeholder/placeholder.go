@@ -0,0 +1,225 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package placeholder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/stringz"
+)
+
+// ${a.b.c} | ${a.b.c:default} | \${a.b.c} -> escaped
+
+const (
+	Prefix          = "${"
+	Suffix          = "}"
+	ValueSeparator  = ":"
+	EscapeCharacter = '\\'
+	ChainSeparator  = " -> "
+)
+
+var (
+	ErrCircularReference = errors.New("nemo: circular placeholder reference")
+)
+
+// Lookup finds the raw value of the key referenced by a placeholder.
+type Lookup func(key string) (any, bool)
+
+type Resolver struct {
+	lookup Lookup
+}
+
+func New(lookup Lookup) *Resolver {
+	return &Resolver{
+		lookup: lookup,
+	}
+}
+
+// Resolve replaces the placeholders of value, maps and slices are resolved recursively into a new copy.
+//
+// A value consisting of one single placeholder keeps the type of the referenced value,
+// an unresolvable placeholder without default value is kept as it is.
+func (r *Resolver) Resolve(value any) (any, error) {
+	return r.resolveValue(value, nil)
+}
+
+// ----------------------------------------------------------------
+
+func (r *Resolver) resolveValue(value any, chain collection.StringSlice) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveText(v, chain)
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, sub := range v {
+			rv, err := r.resolveValue(sub, chain)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = rv
+		}
+
+		return resolved, nil
+	case []any:
+		resolved := make([]any, 0, len(v))
+		for _, sub := range v {
+			rv, err := r.resolveValue(sub, chain)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, rv)
+		}
+
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
+
+func (r *Resolver) resolveText(text string, chain collection.StringSlice) (any, error) {
+	if !strings.Contains(text, Prefix) {
+		return text, nil
+	}
+
+	if strings.HasPrefix(text, Prefix) && findPlaceholderEnd(text, 0) == len(text)-len(Suffix) {
+		return r.resolvePlaceholder(text[len(Prefix):len(text)-len(Suffix)], chain)
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(text); {
+		if text[i] == EscapeCharacter && strings.HasPrefix(text[i+1:], Prefix) {
+			buf.WriteString(Prefix)
+			i += 1 + len(Prefix)
+
+			continue
+		}
+
+		if strings.HasPrefix(text[i:], Prefix) {
+			end := findPlaceholderEnd(text, i)
+			if end < 0 {
+				buf.WriteString(text[i:])
+				break
+			}
+
+			value, err := r.resolvePlaceholder(text[i+len(Prefix):end], chain)
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteString(toString(value))
+			i = end + len(Suffix)
+
+			continue
+		}
+
+		buf.WriteByte(text[i])
+		i++
+	}
+
+	return buf.String(), nil
+}
+
+func (r *Resolver) resolvePlaceholder(expression string, chain collection.StringSlice) (any, error) {
+	key, defaultValue, hasDefault := splitPlaceholder(expression)
+
+	resolvedKey, err := r.resolveText(key, chain)
+	if err != nil {
+		return nil, err
+	}
+	key = strings.TrimSpace(toString(resolvedKey))
+
+	if contains(chain, key) {
+		return nil, fmt.Errorf("%w:[%s]", ErrCircularReference, stringz.Join(ChainSeparator, append(chain, key)...))
+	}
+
+	if value, ok := r.lookup(key); ok {
+		next := make(collection.StringSlice, 0, len(chain)+1)
+		next = append(next, chain...)
+		next = append(next, key)
+
+		return r.resolveValue(value, next)
+	}
+
+	if hasDefault {
+		return r.resolveText(defaultValue, chain)
+	}
+
+	return stringz.Concat(Prefix, expression, Suffix), nil
+}
+
+// ----------------------------------------------------------------
+
+// findPlaceholderEnd returns the index of the Suffix matching the Prefix at start, or -1.
+func findPlaceholderEnd(text string, start int) int {
+	depth := 0
+	for i := start + len(Prefix); i < len(text); {
+		if strings.HasPrefix(text[i:], Prefix) {
+			depth++
+			i += len(Prefix)
+
+			continue
+		}
+
+		if strings.HasPrefix(text[i:], Suffix) {
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+		i++
+	}
+
+	return -1
+}
+
+// splitPlaceholder splits the expression on the first ValueSeparator outside any nested placeholder.
+func splitPlaceholder(expression string) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(expression); i++ {
+		switch {
+		case strings.HasPrefix(expression[i:], Prefix):
+			depth++
+			i += len(Prefix) - 1
+		case strings.HasPrefix(expression[i:], Suffix):
+			depth--
+		case depth == 0 && strings.HasPrefix(expression[i:], ValueSeparator):
+			return expression[:i], expression[i+len(ValueSeparator):], true
+		}
+	}
+
+	return expression, stringz.EmptyString, false
+}
+
+func contains(chain collection.StringSlice, key string) bool {
+	for _, k := range chain {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toString(value any) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	return fmt.Sprintf("%v", value)
+}
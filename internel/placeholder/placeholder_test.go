@@ -0,0 +1,163 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package placeholder
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/mapz"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := collection.MixedMap{
+		"server": collection.MixedMap{
+			"host": "localhost",
+			"port": 8080,
+			"url":  "http://${server.host}:${server.port}",
+		},
+		"app": collection.MixedMap{
+			"name":    "nemo",
+			"profile": "dev",
+			"title":   "${app.name}-${app.profile}",
+			"ref":     "${app.title}",
+			"key":     "name",
+		},
+		"cycle": collection.MixedMap{
+			"a":    "${cycle.b}",
+			"b":    "${cycle.c}",
+			"c":    "${cycle.a}",
+			"self": "${cycle.self}",
+		},
+	}
+
+	resolver := New(func(key string) (any, bool) {
+		return mapz.NestedGet(ctx, key)
+	})
+
+	type args struct {
+		value any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    any
+		wantErr error
+	}{
+		{
+			name: "placeholder#Resolve_plain",
+			args: args{value: "hello"},
+			want: "hello",
+		},
+		{
+			name: "placeholder#Resolve_typed",
+			args: args{value: "${server.port}"},
+			want: 8080,
+		},
+		{
+			name: "placeholder#Resolve_embedded",
+			args: args{value: "${server.url}/api"},
+			want: "http://localhost:8080/api",
+		},
+		{
+			name: "placeholder#Resolve_recursive",
+			args: args{value: "${app.ref}"},
+			want: "nemo-dev",
+		},
+		{
+			name: "placeholder#Resolve_nested_key",
+			args: args{value: "${app.${app.key}}"},
+			want: "nemo",
+		},
+		{
+			name: "placeholder#Resolve_default",
+			args: args{value: "${app.version:1.0.0}"},
+			want: "1.0.0",
+		},
+		{
+			name: "placeholder#Resolve_nested_default",
+			args: args{value: "${app.version:${app.name}:${server.port}}"},
+			want: "nemo:8080",
+		},
+		{
+			name: "placeholder#Resolve_empty_default",
+			args: args{value: "[${app.version:}]"},
+			want: "[]",
+		},
+		{
+			name: "placeholder#Resolve_unresolvable",
+			args: args{value: "${app.version}"},
+			want: "${app.version}",
+		},
+		{
+			name: "placeholder#Resolve_escaped",
+			args: args{value: "\\${app.name} is ${app.name}"},
+			want: "${app.name} is nemo",
+		},
+		{
+			name: "placeholder#Resolve_unterminated",
+			args: args{value: "hello ${app.name"},
+			want: "hello ${app.name",
+		},
+		{
+			name: "placeholder#Resolve_map",
+			args: args{value: collection.MixedMap{"url": "${server.url}", "list": []any{"${app.name}", 1}}},
+			want: collection.MixedMap{"url": "http://localhost:8080", "list": []any{"nemo", 1}},
+		},
+		{
+			name:    "placeholder#Resolve_cycle",
+			args:    args{value: "${cycle.a}"},
+			wantErr: ErrCircularReference,
+		},
+		{
+			name:    "placeholder#Resolve_self",
+			args:    args{value: "${cycle.self}"},
+			wantErr: ErrCircularReference,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.args.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_Resolve_CycleChain(t *testing.T) {
+	ctx := collection.MixedMap{
+		"a": "${b}",
+		"b": "${a}",
+	}
+
+	resolver := New(func(key string) (any, bool) {
+		return mapz.NestedGet(ctx, key)
+	})
+
+	_, err := resolver.Resolve("${a}")
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("Resolve() error = %v, want chain [a -> b -> a]", err)
+	}
+}
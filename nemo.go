@@ -15,3 +15,296 @@
  */
 
 package nemo
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/photowey/nemo/internel/binder"
+	"github.com/photowey/nemo/internel/environment"
+	"github.com/photowey/nemo/internel/eventbus"
+	"github.com/photowey/nemo/internel/loader"
+	"github.com/photowey/nemo/pkg/collection"
+)
+
+// ---------------------------------------------------------------- types
+
+type (
+	Environment       = environment.Environment
+	Option            = environment.Option
+	Options           = environment.Options
+	PropertySource    = environment.PropertySource
+	PropertySources   = environment.PropertySources
+	ActiveProfile     = environment.ActiveProfile
+	SuccessThreshold  = environment.SuccessThreshold
+	StartError        = environment.StartError
+	EnvKeyMapper      = environment.EnvKeyMapper
+	RefreshedEvent    = environment.RefreshedEnvironmentEvent
+	ReloadFailedEvent = environment.ReloadFailedEvent
+
+	MutablePropertySources = environment.MutablePropertySources
+	KeyOrigin              = environment.KeyOrigin
+	PropertyOrigin         = environment.PropertyOrigin
+	Position               = loader.Position
+	PositionDecoder        = loader.PositionDecoder
+
+	ConfigLoader  = loader.ConfigLoader
+	Binder        = binder.Binder
+	BindError     = binder.BindError
+	FieldError    = binder.FieldError
+	Converter     = binder.Converter
+	DataSize      = binder.DataSize
+	Event         = eventbus.Event
+	EventListener = eventbus.EventListener[eventbus.Event]
+	MixedMap      = collection.MixedMap
+	StringSlice   = collection.StringSlice
+)
+
+// ---------------------------------------------------------------- constants
+
+const (
+	DevActiveProfile         = environment.DevActiveProfile
+	TestActiveProfile        = environment.TestActiveProfile
+	ProdActiveProfile        = environment.ProdActiveProfile
+	StagingActiveProfile     = environment.StagingActiveProfile
+	IntegrationActiveProfile = environment.IntegrationActiveProfile
+	DemoActiveProfile        = environment.DemoActiveProfile
+	PreActiveProfile         = environment.PreActiveProfile
+	TrainingActiveProfile    = environment.TrainingActiveProfile
+	BackupActiveProfile      = environment.BackupActiveProfile
+	DefaultActiveProfile     = environment.DefaultActiveProfile
+)
+
+const (
+	PrepareEnvironmentEventName   = environment.PrepareEnvironmentEventName
+	PreLoadEnvironmentEventName   = environment.PreLoadEnvironmentEventName
+	PostLoadEnvironmentEventName  = environment.PostLoadEnvironmentEventName
+	ConfusedEnvironmentEventName  = environment.ConfusedEnvironmentEventName
+	RefreshedEnvironmentEventName = environment.RefreshedEnvironmentEventName
+	ReloadFailedEventName         = environment.ReloadFailedEventName
+)
+
+const (
+	DefaultSystemPropertySourceName  = environment.DefaultSystemPropertySourceName
+	DefaultOptionPropertySourceName  = environment.DefaultOptionPropertySourceName
+	DefaultRuntimePropertySourceName = environment.DefaultRuntimePropertySourceName
+	OptionalPrefix                   = environment.OptionalPrefix
+)
+
+var (
+	NoneSuccessThreshold   = environment.NoneSuccessThreshold
+	AnyoneSuccessThreshold = environment.AnyoneSuccessThreshold
+	AllSuccessThreshold    = environment.AllSuccessThreshold
+)
+
+var (
+	ErrInvalidTarget   = binder.ErrInvalidTarget
+	ErrNotConvertible  = binder.ErrNotConvertible
+	ErrMissingRequired = binder.ErrMissingRequired
+
+	ErrSourceLoad = environment.ErrSourceLoad
+	ErrListener   = environment.ErrListener
+	ErrThreshold  = environment.ErrThreshold
+
+	ErrSourceNotFound  = environment.ErrSourceNotFound
+	ErrSourceNameBlank = environment.ErrSourceNameBlank
+
+	ErrLoaderConflict = loader.ErrLoaderConflict
+	ErrLoaderNotFound = loader.ErrLoaderNotFound
+)
+
+// ---------------------------------------------------------------- options
+
+var (
+	WithAbsolutePaths = environment.WithAbsolutePaths
+	WithConfigNames   = environment.WithConfigNames
+	WithConfigTypes   = environment.WithConfigTypes
+	WithSearchPaths   = environment.WithSearchPaths
+	WithProfiles      = environment.WithProfiles
+	WithSources       = environment.WithSources
+	WithProperties    = environment.WithProperties
+	WithThreshold     = environment.WithThreshold
+
+	WithEnvPrefix         = environment.WithEnvPrefix
+	WithEnvKeyMapper      = environment.WithEnvKeyMapper
+	WithSystemEnvDisabled = environment.WithSystemEnvDisabled
+	WithDotenvExported    = environment.WithDotenvExported
+
+	WithWatch         = environment.WithWatch
+	WithWatchDebounce = environment.WithWatchDebounce
+	WithWatchPolling  = environment.WithWatchPolling
+
+	DefaultEnvKeyMapper = environment.DefaultEnvKeyMapper
+)
+
+// ---------------------------------------------------------------- default environment
+
+var (
+	_environment = environment.New()
+)
+
+// New creates a standalone Environment, independent of the default one.
+func New(sources ...PropertySource) Environment {
+	return environment.New(sources...)
+}
+
+// NewBinder creates a Binder bound to the given key prefix.
+func NewBinder(prefix string) *Binder {
+	return binder.NewBinder(prefix)
+}
+
+// Default returns the package-level Environment used by the functions below.
+func Default() Environment {
+	return _environment
+}
+
+func Start(opts ...Option) error {
+	return _environment.Start(opts...)
+}
+
+func Destroy() error {
+	return _environment.Destroy()
+}
+
+func Refresh(opts ...Option) error {
+	return _environment.Refresh(opts...)
+}
+
+func LoadMap(sourceMap MixedMap) error {
+	return _environment.LoadMap(sourceMap)
+}
+
+func LoadPropertySources(sources ...PropertySource) error {
+	return _environment.LoadPropertySources(sources...)
+}
+
+func Get(key string) (any, bool) {
+	return _environment.Get(key)
+}
+
+func NestedGet(key string) (any, bool) {
+	return _environment.NestedGet(key)
+}
+
+func GetWithOrigin(key string) (any, *KeyOrigin, bool) {
+	return _environment.GetWithOrigin(key)
+}
+
+func Origin(key string) (*KeyOrigin, bool) {
+	return _environment.Origin(key)
+}
+
+func GetString(key string, defaultValue string) (string, error) {
+	return _environment.GetString(key, defaultValue)
+}
+
+func GetInt(key string, defaultValue int) (int, error) {
+	return _environment.GetInt(key, defaultValue)
+}
+
+func GetInt64(key string, defaultValue int64) (int64, error) {
+	return _environment.GetInt64(key, defaultValue)
+}
+
+func GetBool(key string, defaultValue bool) (bool, error) {
+	return _environment.GetBool(key, defaultValue)
+}
+
+func GetFloat64(key string, defaultValue float64) (float64, error) {
+	return _environment.GetFloat64(key, defaultValue)
+}
+
+func GetDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	return _environment.GetDuration(key, defaultValue)
+}
+
+func GetStringSlice(key string, defaultValue StringSlice) (StringSlice, error) {
+	return _environment.GetStringSlice(key, defaultValue)
+}
+
+func GetStringMap(key string, defaultValue MixedMap) (MixedMap, error) {
+	return _environment.GetStringMap(key, defaultValue)
+}
+
+// GetAs | e.g.: nemo.GetAs(nemo.Default(), "server.port", 8080)
+func GetAs[T any](env Environment, key string, defaultValue T) (T, error) {
+	return environment.GetAs(env, key, defaultValue)
+}
+
+func Set(key string, value any) {
+	_environment.Set(key, value)
+}
+
+func NestedSet(key string, value any) {
+	_environment.NestedSet(key, value)
+}
+
+func Contains(key string) bool {
+	return _environment.Contains(key)
+}
+
+func ActiveProfiles() StringSlice {
+	return _environment.ActiveProfiles()
+}
+
+func ActiveProfilesString() string {
+	return _environment.ActiveProfilesString()
+}
+
+func ActiveDefaultProfile() bool {
+	return _environment.ActiveDefaultProfile()
+}
+
+func Sources() MutablePropertySources {
+	return _environment.Sources()
+}
+
+func Bind(prefix string, target any) error {
+	return _environment.Bind(prefix, target)
+}
+
+// ---------------------------------------------------------------- extension
+
+// RegisterLoader registers a custom ConfigLoader, replacing any loader with the same name,
+// the loader that claims the same extension at the equal priority of another one is rejected.
+func RegisterLoader(configLoader ConfigLoader) error {
+	return loader.Register(configLoader)
+}
+
+func UnregisterLoader(name string) bool {
+	return loader.Unregister(name)
+}
+
+// RegisterConverter registers the Converter used by Bind for the target type.
+func RegisterConverter(target reflect.Type, converter Converter) {
+	binder.RegisterConverter(target, converter)
+}
+
+// RegisterTimeLayouts appends the layouts used by Bind to parse time.Time values.
+func RegisterTimeLayouts(layouts ...string) {
+	binder.RegisterTimeLayouts(layouts...)
+}
+
+// RegisterListener registers an EventListener on the environment eventbus.
+func RegisterListener(listener EventListener) error {
+	return eventbus.Register(listener)
+}
+
+// UnregisterListener removes the EventListener from the environment eventbus, it's matched by ==, e.g.: the same pointer,
+// or by the name if its type is uncomparable.
+func UnregisterListener(listener EventListener) error {
+	return eventbus.Unregister(listener)
+}
+
+// Post publishes an event to the registered listeners synchronously.
+func Post(event Event) error {
+	return eventbus.Post(event)
+}
+
+func NewStandardAnyEvent(name string, data any) Event {
+	return eventbus.NewStandardAnyEvent(name, data)
+}
+
+func RegisterSpecialEnvironment(key, value string) {
+	environment.RegisterSpecialEnvironment(key, value)
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright © 2023 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nemo
+
+import (
+	"embed"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/photowey/nemo/pkg/collection"
+	"github.com/photowey/nemo/pkg/ordered"
+)
+
+//go:embed tests/testdata/application.toml
+var testdata embed.FS
+
+type testDatabase struct {
+	Driver string `binder:"driver"`
+	Host   string `binder:"host"`
+	Port   int64  `binder:"port"`
+}
+
+type testListener struct {
+	events collection.StringSlice
+}
+
+func (l *testListener) Order() int64 {
+	return ordered.DefaultPriority
+}
+
+func (l *testListener) Name() string {
+	return "nemo.test.listener"
+}
+
+func (l *testListener) Topic() collection.StringSlice {
+	return collection.StringSlice{PrepareEnvironmentEventName, PostLoadEnvironmentEventName}
+}
+
+func (l *testListener) Supports(event string) bool {
+	return collection.ArrayContains(l.Topic(), event)
+}
+
+func (l *testListener) OnEvent(event Event) error {
+	l.events = append(l.events, event.Name())
+
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	listener := &testListener{}
+	if err := RegisterListener(listener); err != nil {
+		t.Fatalf("RegisterListener() error = %v", err)
+	}
+	t.Cleanup(func() { _ = UnregisterListener(listener) })
+
+	err := Start(
+		WithSources(PropertySource{Priority: 1, Property: "toml", FilePath: "tests/testdata", Name: "application", Suffix: "toml"}),
+		WithProperties(MixedMap{"hello": "world"}),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	type args struct {
+		key string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   any
+		wantOk bool
+	}{
+		{
+			name:   "nemo#NestedGet_toml",
+			args:   args{key: "database.host"},
+			want:   "192.168.1.10",
+			wantOk: true,
+		},
+		{
+			name:   "nemo#NestedGet_properties",
+			args:   args{key: "hello"},
+			want:   "world",
+			wantOk: true,
+		},
+		{
+			name:   "nemo#NestedGet_not_found",
+			args:   args{key: "database.none.host"},
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NestedGet(tt.args.key)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NestedGet() got = %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("NestedGet() ok = %v, want %v", ok, tt.wantOk)
+			}
+		})
+	}
+
+	want := collection.StringSlice{PrepareEnvironmentEventName, PostLoadEnvironmentEventName}
+	if !reflect.DeepEqual(listener.events, want) {
+		t.Errorf("RegisterListener() events = %v, want %v", listener.events, want)
+	}
+}
+
+func TestStart_EmbedFS(t *testing.T) {
+	env := New()
+	err := env.Start(
+		WithSources(PropertySource{Priority: 1, Property: "embed", FS: testdata, FilePath: "tests/testdata", Name: "application.toml"}),
+		WithSystemEnvDisabled(),
+	)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if got, ok := env.NestedGet("database.host"); !ok || "192.168.1.10" != got {
+		t.Errorf("NestedGet() got = %v, want %v", got, "192.168.1.10")
+	}
+}
+
+func TestBind(t *testing.T) {
+	env := New()
+	if err := env.LoadMap(MixedMap{
+		"database": MixedMap{
+			"driver": "mysql",
+			"host":   "192.168.1.10",
+			"port":   int64(3306),
+		},
+	}); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	got := testDatabase{}
+	if err := env.Bind("database", &got); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	want := testDatabase{Driver: "mysql", Host: "192.168.1.10", Port: 3306}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	env := New()
+	if err := env.LoadMap(MixedMap{"database": MixedMap{"port": "3306"}}); err != nil {
+		t.Fatalf("LoadMap() error = %v", err)
+	}
+
+	if got, err := GetAs(env, "database.port", 0); err != nil || 3306 != got {
+		t.Errorf("GetAs() got = %v, error = %v, want %v", got, err, 3306)
+	}
+	if got, err := GetAs(env, "database.timeout", 5*time.Second); err != nil || 5*time.Second != got {
+		t.Errorf("GetAs() got = %v, error = %v, want %v", got, err, 5*time.Second)
+	}
+
+	var fe *FieldError
+	if got, err := GetAs(env, "database.port", false); !errors.As(err, &fe) || got {
+		t.Errorf("GetAs() got = %v, error = %v, want *FieldError", got, err)
+	}
+}
@@ -17,6 +17,8 @@
 package mapz
 
 import (
+	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -32,9 +34,6 @@ func NestedGet(ctx map[string]any, key string) (any, bool) {
 	for i, k := range keys {
 		value, ok := current[k]
 		if !ok {
-			if i == len(keys)-1 {
-				return current, true
-			}
 			return nil, false
 		}
 
@@ -70,6 +69,8 @@ func NestedSet(ctx map[string]any, key string, value any) {
 	current[lastKey] = value
 }
 
+// MergeMixedMaps merges the source into the target recursively,
+// the nested maps and slices of the source are copied, so the later merges never modify the source.
 func MergeMixedMaps(target map[string]any, source map[string]any) {
 	for key, sourceValue := range source {
 		targetValue, exists := target[key]
@@ -78,11 +79,86 @@ func MergeMixedMaps(target map[string]any, source map[string]any) {
 			if IsMixedMap(targetValue) && IsMixedMap(sourceValue) {
 				MergeMixedMaps(targetValue.(map[string]any), sourceValue.(map[string]any))
 			} else {
-				target[key] = sourceValue
+				target[key] = DeepCopy(sourceValue)
 			}
 		} else {
-			target[key] = sourceValue
+			target[key] = DeepCopy(sourceValue)
+		}
+	}
+}
+
+// DeepCopy copies the map[string]any and []any values recursively, the other values are returned as they are.
+func DeepCopy(src any) any {
+	switch v := src.(type) {
+	case map[string]any:
+		dst := make(map[string]any, len(v))
+		for key, value := range v {
+			dst[key] = DeepCopy(value)
+		}
+
+		return dst
+	case []any:
+		dst := make([]any, len(v))
+		for i, value := range v {
+			dst[i] = DeepCopy(value)
+		}
+
+		return dst
+	default:
+		return src
+	}
+}
+
+// Canonicalize copies the value into the canonical tree: the nested maps are converted into map[string]any,
+// the non-string keys are stringified, the slices are converted into []any, e.g.: the map[any]any of yaml.v2
+func Canonicalize(src any) any {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		dst := make(map[string]any, len(v))
+		for key, value := range v {
+			dst[key] = Canonicalize(value)
 		}
+
+		return dst
+	case []any:
+		dst := make([]any, len(v))
+		for i, value := range v {
+			dst[i] = Canonicalize(value)
+		}
+
+		return dst
+	case []byte:
+		return v
+	}
+
+	sv := reflect.ValueOf(src)
+	switch sv.Kind() {
+	case reflect.Map:
+		dst := make(map[string]any, sv.Len())
+		iter := sv.MapRange()
+		for iter.Next() {
+			dst[fmt.Sprintf("%v", iter.Key().Interface())] = Canonicalize(iter.Value().Interface())
+		}
+
+		return dst
+	case reflect.Slice, reflect.Array:
+		dst := make([]any, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			dst[i] = Canonicalize(sv.Index(i).Interface())
+		}
+
+		return dst
+	default:
+		return src
+	}
+}
+
+// CanonicalizeMap canonicalizes the values of the ctx in place.
+func CanonicalizeMap(ctx map[string]any) {
+	for key, value := range ctx {
+		ctx[key] = Canonicalize(value)
 	}
 }
 
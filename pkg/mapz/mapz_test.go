@@ -76,6 +76,20 @@ func TestNestedGet(t *testing.T) {
 			want:   nil,
 			wantOk: false,
 		},
+		{
+			name: "mapz#NestedGet_false_3",
+			args: args{
+				ctx: collection.MixedMap{
+					"a": 1,
+					"b": collection.MixedMap{
+						"c": 2,
+					},
+				},
+				key: "b.d",
+			},
+			want:   nil,
+			wantOk: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -303,6 +317,78 @@ func TestMergeMixedMaps(t *testing.T) {
 	}
 }
 
+func TestMergeMixedMaps_SourceUntouched(t *testing.T) {
+	source := collection.MixedMap{
+		"b": collection.MixedMap{
+			"c": 2,
+		},
+		"l": []any{collection.MixedMap{"x": 1}},
+	}
+
+	target := make(collection.MixedMap)
+	MergeMixedMaps(target, source)
+	MergeMixedMaps(target, collection.MixedMap{"b": collection.MixedMap{"c": 3}})
+	target["l"].([]any)[0].(collection.MixedMap)["x"] = 2
+
+	want := collection.MixedMap{
+		"b": collection.MixedMap{
+			"c": 2,
+		},
+		"l": []any{collection.MixedMap{"x": 1}},
+	}
+	if !reflect.DeepEqual(source, want) {
+		t.Errorf("MergeMixedMaps() source = %v, want %v", source, want)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	src := collection.MixedMap{
+		"a": 1,
+		"b": collection.MixedMap{"c": []any{1, collection.MixedMap{"d": 2}}},
+	}
+
+	dst := DeepCopy(src)
+	if !reflect.DeepEqual(dst, src) {
+		t.Errorf("DeepCopy() = %v, want %v", dst, src)
+	}
+
+	dst.(collection.MixedMap)["b"].(collection.MixedMap)["c"].([]any)[1].(collection.MixedMap)["d"] = 3
+	if got := src["b"].(collection.MixedMap)["c"].([]any)[1].(collection.MixedMap)["d"]; got != 2 {
+		t.Errorf("DeepCopy() the source is modified, got %v", got)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	src := collection.MixedMap{
+		"a": map[any]any{
+			"b":  1,
+			true: []map[string]any{{"c": map[any]any{1: "d"}}},
+		},
+		"e": []string{"f"},
+		"g": []byte("h"),
+	}
+
+	want := collection.MixedMap{
+		"a": collection.MixedMap{
+			"b":    1,
+			"true": []any{collection.MixedMap{"c": collection.MixedMap{"1": "d"}}},
+		},
+		"e": []any{"f"},
+		"g": []byte("h"),
+	}
+	if got := Canonicalize(src); !reflect.DeepEqual(got, want) {
+		t.Errorf("Canonicalize() = %v, want %v", got, want)
+	}
+	if _, ok := src["a"].(map[any]any); !ok {
+		t.Errorf("Canonicalize() the source is modified")
+	}
+
+	CanonicalizeMap(src)
+	if !reflect.DeepEqual(src, want) {
+		t.Errorf("CanonicalizeMap() = %v, want %v", src, want)
+	}
+}
+
 func TestContains(t *testing.T) {
 	type args[K comparable, V any] struct {
 		key K
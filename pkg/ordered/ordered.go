@@ -47,8 +47,9 @@ func NewSorter[T Ordered](actors ...T) Sorter[T] {
 	return sorter
 }
 
+// Sort | the actors of the same order keep their original order.
 func Sort[T Ordered](sorter Sorter[T], sign int) {
-	sort.Slice(sorter, func(i, j int) bool {
+	sort.SliceStable(sorter, func(i, j int) bool {
 		if sign > 0 {
 			return sorter[i].Order() < sorter[j].Order()
 		}
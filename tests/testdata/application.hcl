@@ -0,0 +1,36 @@
+# ---------------------------------------------------------------- server
+
+server {
+  port = 9527
+  ratio = 0.75
+  hosts = ["a.com", "b.com"]
+}
+
+# ---------------------------------------------------------------- nemo
+
+nemo "datasource" "primary" {
+  driver = "mysql"
+  host = "192.168.1.10"
+  port = 3306
+  enabled = true
+}
+
+nemo "datasource" "replica" {
+  host = "192.168.1.11"
+}
+
+listener {
+  port = 80
+}
+
+listener {
+  port = 443
+}
+
+banner = <<EOT
+hello nemo
+EOT
+
+labels = {
+  env = "dev"
+}